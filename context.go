@@ -20,11 +20,22 @@ type Context interface {
 	Rand() *rand.Rand
 
 	// Sleep for the duration d. Can return a terminal error in the case where the invocation was cancelled mid-sleep.
-	Sleep(d time.Duration) error
+	Sleep(d time.Duration, opts ...options.SleepOption) error
 	// After is an alternative to Context.Sleep which allows you to complete other tasks concurrently
 	// with the sleep. This is particularly useful when combined with Context.Select to race between
 	// the sleep and other Selectable operations.
-	After(d time.Duration) After
+	After(d time.Duration, opts ...options.SleepOption) After
+	// Timer is an alternative to Context.After which returns a timer that can be cancelled or reset to a new
+	// duration, eg to implement an inactivity timeout. Cancelling only affects Timer.Done: the journaled sleep
+	// still completes, so a cancelled timer that was passed to Context.Select is still returned by the Selector.
+	Timer(d time.Duration, opts ...options.SleepOption) Timer
+	// WithDeadline gives the handler a durable deadline: once it has passed, every blocking Response, Result, Output
+	// and Done, as well as Sleep, returns [ErrDeadlineExceeded] instead of waiting. Operations which had already
+	// completed when they are awaited still return their result. The deadline is journaled like a sleep, and an
+	// operation which has to wait races against it like Context.Select, journaling a selector entry, so the same
+	// operations fail on replay. Calling it again replaces the deadline, and the zero time removes it.
+	// Context.Select is not affected.
+	WithDeadline(deadline time.Time, opts ...options.SleepOption)
 
 	// Service gets a Service accessor by service and method name
 	// Note: use the CallAs helper function to deserialise return values
@@ -53,11 +64,25 @@ type Context interface {
 	// rejected with a particular error.
 	RejectAwakeable(id string, reason error)
 
+	// CancelInvocation sends a cancellation signal to the invocation with the provided ID, for example one
+	// obtained from [InvocationHandle.InvocationId]. It requires service protocol V2 to be negotiated with Restate.
+	CancelInvocation(invocationId string) error
+
+	// AttachInvocation returns a future on the response of the invocation with the provided ID, which needn't have
+	// been started by this handler. It requires service protocol V3 to be negotiated with Restate.
+	// Note: use the AttachInvocationAs helper function to avoid having to pass a output pointer to AttachFuture.Response()
+	AttachInvocation(invocationId string, opts ...options.AttachOption) (AttachFuture, error)
+	// GetInvocationOutput is like AttachInvocation, except that the future completes immediately if the invocation
+	// has not completed yet. It requires service protocol V3 to be negotiated with Restate.
+	GetInvocationOutput(invocationId string, opts ...options.AttachOption) (InvocationOutputFuture, error)
+
 	// Select returns an iterator over blocking Restate operations (sleep, call, awakeable)
 	// which allows you to safely run them in parallel. The Selector will store the order
 	// that things complete in durably inside Restate, so that on replay the same order
 	// can be used. This avoids non-determinism. It is *not* safe to use goroutines or channels
 	// outside of Context.Run functions, as they do not behave deterministically.
+	// If several operations have already completed when Selector.Select is called, the one journaled first is returned.
+	// See also the helpers [SelectIndexed], [FirstOf], [AnyOf], [All], [AwaitTimeout], [ParallelFor] and [Map].
 	Select(futs ...Selectable) Selector
 }
 
@@ -91,6 +116,22 @@ type CallClient interface {
 type SendClient interface {
 	// Send makes a one-way call which is executed in the background
 	Send(input any, delay time.Duration) error
+	// SendAt makes a one-way call which is executed in the background at the time at, or immediately if at has
+	// already passed
+	SendAt(input any, at time.Time) error
+	// SendWithHandle makes a one-way call like Send, returning a handle on the invocation
+	SendWithHandle(input any, delay time.Duration) (InvocationHandle, error)
+}
+
+// InvocationHandle is a handle on an invocation started by a call or a one-way call
+type InvocationHandle interface {
+	// InvocationId blocks on the ID of the invocation, which can be stored or sent to another service, for example
+	// to cancel the invocation with Context.CancelInvocation. It requires service protocol V2 to be negotiated with
+	// Restate.
+	InvocationId() (string, error)
+	// Cancel sends a cancellation signal to the invocation, which will typically cause its response to be
+	// a terminal error matching [ErrCancelled]. It requires service protocol V2 to be negotiated with Restate.
+	Cancel() error
 }
 
 // ResponseFuture is a handle on a potentially not-yet completed outbound call.
@@ -99,6 +140,24 @@ type ResponseFuture interface {
 	// It is *not* safe to call this in a goroutine - use Context.Select if you
 	// want to wait on multiple results at once.
 	Response(output any) error
+	InvocationHandle
+	Selectable
+}
+
+// AttachFuture is a handle on the response of an invocation obtained with Context.AttachInvocation
+type AttachFuture interface {
+	// Response blocks on the response of the invocation and stores it in output, or returns the associated error
+	// It is *not* safe to call this in a goroutine - use Context.Select if you
+	// want to wait on multiple results at once.
+	Response(output any) error
+	Selectable
+}
+
+// InvocationOutputFuture is a handle on the output of an invocation obtained with Context.GetInvocationOutput
+type InvocationOutputFuture interface {
+	// Output blocks on the output of the invocation. If the invocation had not completed, ok is false; otherwise
+	// its response is stored in output, or the associated error is returned.
+	Output(output any) (ok bool, err error)
 	Selectable
 }
 
@@ -153,6 +212,21 @@ type After interface {
 	Selectable
 }
 
+// Timer is a durable timer returned by Context.Timer, which unlike [After] can be stopped or restarted
+type Timer interface {
+	// Done blocks until the timer fires, or returns [ErrTimerCancelled] if it was cancelled.
+	// It is *not* safe to call this in a goroutine - use Context.Select if you want to wait on multiple
+	// results at once.
+	Done() error
+	// Cancel stops the timer, so that Done returns [ErrTimerCancelled]. The journaled sleep still completes, so
+	// the timer should no longer be passed to Context.Select.
+	Cancel()
+	// Reset restarts the timer to fire after d, even if it had already fired or been cancelled. Selectors which
+	// were already given the timer keep waiting on the old duration.
+	Reset(d time.Duration)
+	Selectable
+}
+
 // ObjectContext is an extension of [Context] which can be used in exclusive-mode Virtual Object handlers,
 // giving mutable access to state.
 type ObjectContext interface {
@@ -168,6 +242,43 @@ type ObjectSharedContext interface {
 	KeyValueReader
 }
 
+// WorkflowSharedContext is an extension of [ObjectSharedContext] which can be used in shared-mode Workflow handlers,
+// giving read-only access to a snapshot of state, and access to the durable promises of the workflow.
+type WorkflowSharedContext interface {
+	ObjectSharedContext
+	// Promise returns a named Restate durable Promise that can be resolved or rejected during the workflow execution.
+	// The workflow run handler may wait on the promise, and shared handlers may resolve or reject it.
+	// Note: use the PromiseAs helper function to avoid having to pass output pointers
+	Promise(name string, options ...options.PromiseOption) DurablePromise
+}
+
+// WorkflowContext is an extension of [WorkflowSharedContext] which is provided to the run handler of a Workflow,
+// giving mutable access to state.
+type WorkflowContext interface {
+	WorkflowSharedContext
+	KeyValueWriter
+}
+
+// DurablePromise is a named promise scoped to a single workflow execution. It can be resolved or rejected
+// exactly once, by any handler of the workflow, and its result is durably stored by Restate.
+type DurablePromise interface {
+	// Result blocks on receiving the result of the promise, storing the value it was
+	// resolved with in output or otherwise returning the error it was rejected with.
+	// It is *not* safe to call this in a goroutine - use Context.Select if you
+	// want to wait on multiple results at once.
+	Result(output any) error
+	// Peek retrieves the result of the promise if it has already been completed, without blocking
+	// on it otherwise. ok is false if the promise has not been completed yet.
+	Peek(output any) (ok bool, err error)
+	// Resolve completes the promise with a value. A terminal error is returned if the promise was
+	// already completed.
+	Resolve(value any) error
+	// Reject completes the promise with a failure. A terminal error is returned if the promise was
+	// already completed.
+	Reject(reason error) error
+	Selectable
+}
+
 // KeyValueReader is the set of read-only methods which can be used in all Virtual Object handlers.
 type KeyValueReader interface {
 	// Get gets value associated with key and stores it in value
@@ -176,6 +287,11 @@ type KeyValueReader interface {
 	Get(key string, value any, options ...options.GetOption) error
 	// Keys returns a list of all associated key
 	Keys() []string
+	// Prefetch starts fetching the values of keys from Restate without waiting for them, so that subsequent
+	// calls to Get for these keys don't each wait for a round trip. This is only useful with lazy state
+	// (see [WithLazyState]); otherwise, state is already sent with the invocation. Each prefetched key is
+	// journaled like a Get, and the next Get of the key uses that entry rather than journaling another.
+	Prefetch(keys ...string)
 	// Key retrieves the key for this virtual object invocation. This is a no-op and is
 	// always safe to call.
 	Key() string
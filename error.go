@@ -9,6 +9,20 @@ import (
 var (
 	// ErrKeyNotFound is returned when there is no state value for a key
 	ErrKeyNotFound = errors.ErrKeyNotFound
+	// ErrTimeout is returned by [AwaitTimeout] when the timeout elapses before the operation completes
+	ErrTimeout = errors.ErrTimeout
+	// ErrCancelled matches, with errors.Is, the terminal error that Restate fails operations with when the
+	// invocation is cancelled, so that handlers can detect cancellation and run compensations. Other errors with
+	// code 409, such as completing an already completed promise, don't match it.
+	ErrCancelled = errors.ErrCancelled
+	// ErrDeadlineExceeded is returned by blocking operations once the deadline set with Context.WithDeadline has
+	// passed. It has the same code as ErrTimeout, but doesn't match it with errors.Is.
+	ErrDeadlineExceeded = errors.ErrDeadlineExceeded
+	// ErrTimerCancelled is returned by [Timer.Done] if the timer was cancelled. It has the code of ErrCancelled, but
+	// doesn't match it with errors.Is.
+	ErrTimerCancelled = errors.ErrTimerCancelled
+	// ErrNoFutures is returned by [FirstOf] and [AnyOf] when they are given no futures, as none of them can complete
+	ErrNoFutures = errors.ErrNoFutures
 )
 
 // Code is a numeric status code for an error, typically a HTTP status code.
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/internal/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -38,3 +40,13 @@ func TestCombine(t *testing.T) {
 	require.True(t, IsTerminalError(err))
 	require.EqualValues(t, 100, ErrorCode(err))
 }
+
+func TestCancelled(t *testing.T) {
+	require.ErrorIs(t, ErrCancelled, ErrCancelled)
+	require.ErrorIs(t, errors.ErrorFromFailure(&protocol.Failure{Code: 409, Message: "canceled"}), ErrCancelled)
+
+	require.NotErrorIs(t, errors.ErrorFromFailure(&protocol.Failure{Code: 409, Message: "promise approval already completed"}), ErrCancelled)
+	require.NotErrorIs(t, errors.ErrorFromFailure(&protocol.Failure{Code: 400, Message: "canceled"}), ErrCancelled)
+	require.NotErrorIs(t, TerminalError(fmt.Errorf("conflict"), 409), ErrCancelled)
+	require.NotErrorIs(t, WithErrorCode(fmt.Errorf("retry"), 409), ErrCancelled)
+}
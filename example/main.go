@@ -4,11 +4,15 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
 	restate "github.com/restatedev/sdk-go"
 	"github.com/restatedev/sdk-go/server"
 )
 
+//go:generate go run github.com/restatedev/sdk-go/restate-gen -type checkout,userSession,ticketService
+
 func main() {
 	server := server.NewRestate().
 		// Handlers can be inferred from object methods
@@ -19,7 +23,11 @@ func main() {
 		Bind(health).
 		Bind(bigCounter)
 
-	if err := server.Start(context.Background(), ":9080"); err != nil {
+	// on SIGTERM, stop accepting invocations and drain those in-flight before exiting
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	if err := server.Start(ctx, ":9080"); err != nil {
 		slog.Error("application exited unexpectedly", "err", err.Error())
 		os.Exit(1)
 	}
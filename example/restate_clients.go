@@ -0,0 +1,170 @@
+// Code generated by restate-gen. DO NOT EDIT.
+
+package main
+
+import (
+	restate "github.com/restatedev/sdk-go"
+	ingress "github.com/restatedev/sdk-go/ingress"
+)
+
+// CheckoutClient is a typed client for the Checkout Service, for use within a handler
+type CheckoutClient interface {
+	Payment() restate.TypedClient[PaymentRequest, PaymentResponse]
+}
+
+type checkoutClient struct {
+	ctx restate.Context
+}
+
+// NewCheckoutClient returns a typed client for the Checkout Service
+func NewCheckoutClient(ctx restate.Context) CheckoutClient {
+	return &checkoutClient{ctx}
+}
+
+func (c *checkoutClient) Payment() restate.TypedClient[PaymentRequest, PaymentResponse] {
+	return restate.ClientAs[PaymentRequest, PaymentResponse](c.ctx.Service("Checkout", "Payment"))
+}
+
+// CheckoutIngressClient is a typed client for the Checkout Service, for use through the Restate ingress
+type CheckoutIngressClient interface {
+	Payment() ingress.Requester[PaymentRequest, PaymentResponse]
+}
+
+type checkoutIngressClient struct {
+	client *ingress.Client
+}
+
+// NewCheckoutIngressClient returns a typed client for the Checkout Service which uses the provided ingress client
+func NewCheckoutIngressClient(client *ingress.Client) CheckoutIngressClient {
+	return &checkoutIngressClient{client}
+}
+
+func (c *checkoutIngressClient) Payment() ingress.Requester[PaymentRequest, PaymentResponse] {
+	return ingress.Service[PaymentRequest, PaymentResponse](c.client, "Checkout", "Payment")
+}
+
+// UserSessionClient is a typed client for the UserSession Virtual Object, for use within a handler
+type UserSessionClient interface {
+	AddTicket() restate.TypedClient[string, bool]
+	Checkout() restate.TypedClient[restate.Void, bool]
+	ExpireTicket() restate.TypedClient[string, restate.Void]
+}
+
+type userSessionClient struct {
+	ctx restate.Context
+	key string
+}
+
+// NewUserSessionClient returns a typed client for the UserSession Virtual Object
+func NewUserSessionClient(ctx restate.Context, key string) UserSessionClient {
+	return &userSessionClient{ctx, key}
+}
+
+func (c *userSessionClient) AddTicket() restate.TypedClient[string, bool] {
+	return restate.ClientAs[string, bool](c.ctx.Object("UserSession", c.key, "AddTicket"))
+}
+
+func (c *userSessionClient) Checkout() restate.TypedClient[restate.Void, bool] {
+	return restate.ClientAs[restate.Void, bool](c.ctx.Object("UserSession", c.key, "Checkout"))
+}
+
+func (c *userSessionClient) ExpireTicket() restate.TypedClient[string, restate.Void] {
+	return restate.ClientAs[string, restate.Void](c.ctx.Object("UserSession", c.key, "ExpireTicket"))
+}
+
+// UserSessionIngressClient is a typed client for the UserSession Virtual Object, for use through the Restate ingress
+type UserSessionIngressClient interface {
+	AddTicket() ingress.Requester[string, bool]
+	Checkout() ingress.Requester[restate.Void, bool]
+	ExpireTicket() ingress.Requester[string, restate.Void]
+}
+
+type userSessionIngressClient struct {
+	client *ingress.Client
+	key    string
+}
+
+// NewUserSessionIngressClient returns a typed client for the UserSession Virtual Object which uses the provided ingress client
+func NewUserSessionIngressClient(client *ingress.Client, key string) UserSessionIngressClient {
+	return &userSessionIngressClient{client, key}
+}
+
+func (c *userSessionIngressClient) AddTicket() ingress.Requester[string, bool] {
+	return ingress.Object[string, bool](c.client, "UserSession", c.key, "AddTicket")
+}
+
+func (c *userSessionIngressClient) Checkout() ingress.Requester[restate.Void, bool] {
+	return ingress.Object[restate.Void, bool](c.client, "UserSession", c.key, "Checkout")
+}
+
+func (c *userSessionIngressClient) ExpireTicket() ingress.Requester[string, restate.Void] {
+	return ingress.Object[string, restate.Void](c.client, "UserSession", c.key, "ExpireTicket")
+}
+
+// TicketServiceClient is a typed client for the TicketService Virtual Object, for use within a handler
+type TicketServiceClient interface {
+	MarkAsSold() restate.TypedClient[restate.Void, restate.Void]
+	Reserve() restate.TypedClient[restate.Void, bool]
+	Status() restate.TypedClient[restate.Void, TicketStatus]
+	Unreserve() restate.TypedClient[restate.Void, restate.Void]
+}
+
+type ticketServiceClient struct {
+	ctx restate.Context
+	key string
+}
+
+// NewTicketServiceClient returns a typed client for the TicketService Virtual Object
+func NewTicketServiceClient(ctx restate.Context, key string) TicketServiceClient {
+	return &ticketServiceClient{ctx, key}
+}
+
+func (c *ticketServiceClient) MarkAsSold() restate.TypedClient[restate.Void, restate.Void] {
+	return restate.ClientAs[restate.Void, restate.Void](c.ctx.Object("TicketService", c.key, "MarkAsSold"))
+}
+
+func (c *ticketServiceClient) Reserve() restate.TypedClient[restate.Void, bool] {
+	return restate.ClientAs[restate.Void, bool](c.ctx.Object("TicketService", c.key, "Reserve"))
+}
+
+func (c *ticketServiceClient) Status() restate.TypedClient[restate.Void, TicketStatus] {
+	return restate.ClientAs[restate.Void, TicketStatus](c.ctx.Object("TicketService", c.key, "Status"))
+}
+
+func (c *ticketServiceClient) Unreserve() restate.TypedClient[restate.Void, restate.Void] {
+	return restate.ClientAs[restate.Void, restate.Void](c.ctx.Object("TicketService", c.key, "Unreserve"))
+}
+
+// TicketServiceIngressClient is a typed client for the TicketService Virtual Object, for use through the Restate ingress
+type TicketServiceIngressClient interface {
+	MarkAsSold() ingress.Requester[restate.Void, restate.Void]
+	Reserve() ingress.Requester[restate.Void, bool]
+	Status() ingress.Requester[restate.Void, TicketStatus]
+	Unreserve() ingress.Requester[restate.Void, restate.Void]
+}
+
+type ticketServiceIngressClient struct {
+	client *ingress.Client
+	key    string
+}
+
+// NewTicketServiceIngressClient returns a typed client for the TicketService Virtual Object which uses the provided ingress client
+func NewTicketServiceIngressClient(client *ingress.Client, key string) TicketServiceIngressClient {
+	return &ticketServiceIngressClient{client, key}
+}
+
+func (c *ticketServiceIngressClient) MarkAsSold() ingress.Requester[restate.Void, restate.Void] {
+	return ingress.Object[restate.Void, restate.Void](c.client, "TicketService", c.key, "MarkAsSold")
+}
+
+func (c *ticketServiceIngressClient) Reserve() ingress.Requester[restate.Void, bool] {
+	return ingress.Object[restate.Void, bool](c.client, "TicketService", c.key, "Reserve")
+}
+
+func (c *ticketServiceIngressClient) Status() ingress.Requester[restate.Void, TicketStatus] {
+	return ingress.Object[restate.Void, TicketStatus](c.client, "TicketService", c.key, "Status")
+}
+
+func (c *ticketServiceIngressClient) Unreserve() ingress.Requester[restate.Void, restate.Void] {
+	return ingress.Object[restate.Void, restate.Void](c.client, "TicketService", c.key, "Unreserve")
+}
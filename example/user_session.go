@@ -88,7 +88,7 @@ func (u *userSession) Checkout(ctx restate.ObjectContext, _ restate.Void) (bool,
 
 	timeout := ctx.After(time.Minute)
 
-	request, err := restate.CallAs[PaymentResponse](ctx.Object(CheckoutServiceName, "", "Payment")).
+	request, err := NewCheckoutClient(ctx).Payment().
 		RequestFuture(PaymentRequest{UserID: userId, Tickets: tickets})
 	if err != nil {
 		return false, err
@@ -112,8 +112,7 @@ func (u *userSession) Checkout(ctx restate.ObjectContext, _ restate.Void) (bool,
 	ctx.Log().Info("payment details", "id", response.ID, "price", response.Price)
 
 	for _, ticket := range tickets {
-		call := ctx.Object(TicketServiceName, ticket, "MarkAsSold")
-		if err := call.Send(nil, 0); err != nil {
+		if err := NewTicketServiceClient(ctx, ticket).MarkAsSold().Send(restate.Void{}, 0); err != nil {
 			return false, err
 		}
 	}
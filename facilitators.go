@@ -1,6 +1,11 @@
 package restate
 
 import (
+	stderrors "errors"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/restatedev/sdk-go/internal/options"
 )
 
@@ -11,6 +16,75 @@ func GetAs[T any](ctx ObjectSharedContext, key string, options ...options.GetOpt
 	return
 }
 
+// GetMany gets the values of keys, returning a map from each key which has a value to its value. The values are
+// fetched with [KeyValueReader.Prefetch], so that with lazy state they are requested from Restate together.
+func GetMany[T any](ctx KeyValueReader, keys []string, options ...options.GetOption) (map[string]T, error) {
+	ctx.Prefetch(keys...)
+
+	values := make(map[string]T, len(keys))
+	for _, key := range keys {
+		var value T
+		if err := ctx.Get(key, &value, options...); err != nil {
+			if stderrors.Is(err, ErrKeyNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// GetAll gets the values of all keys, which must all be of the same type, returning a map from each key to its value
+func GetAll[T any](ctx KeyValueReader, options ...options.GetOption) (map[string]T, error) {
+	return GetMany[T](ctx, ctx.Keys(), options...)
+}
+
+// GetPrefix gets the values of all keys starting with prefix, returning a map from each key to its value
+func GetPrefix[T any](ctx KeyValueReader, prefix string, options ...options.GetOption) (map[string]T, error) {
+	return GetMany[T](ctx, KeysWithPrefix(ctx, prefix), options...)
+}
+
+// KeysWithPrefix returns the keys starting with prefix
+func KeysWithPrefix(ctx KeyValueReader, prefix string) []string {
+	var keys []string
+	for _, key := range ctx.Keys() {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// SetMany sets the value of each key in values. Keys are set in sorted order, so that the journal is the same
+// on every execution.
+func SetMany[T any](ctx KeyValueWriter, values map[string]T, options ...options.SetOption) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := ctx.Set(key, values[key], options...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClearPrefix deletes all keys starting with prefix, returning the keys that were deleted
+func ClearPrefix(ctx interface {
+	KeyValueReader
+	KeyValueWriter
+}, prefix string) []string {
+	keys := KeysWithPrefix(ctx, prefix)
+	for _, key := range keys {
+		ctx.Clear(key)
+	}
+	return keys
+}
+
 // RunAs executes a Run function on a [Context], returning a typed response instead of accepting a pointer
 func RunAs[T any](ctx Context, fn func(ctx RunContext) (T, error), options ...options.RunOption) (output T, err error) {
 	err = ctx.Run(func(ctx RunContext) (any, error) {
@@ -46,6 +120,100 @@ func AwakeableAs[T any](ctx Context, options ...options.AwakeableOption) TypedAw
 	return typedAwakeable[T]{ctx.Awakeable(options...)}
 }
 
+// TypedAttachFuture is an extension of [AttachFuture] which returns typed responses instead of accepting a pointer
+type TypedAttachFuture[O any] interface {
+	// Response blocks on the response of the invocation and returns it, or the associated error
+	// It is *not* safe to call this in a goroutine - use Context.Select if you
+	// want to wait on multiple results at once.
+	Response() (O, error)
+	Selectable
+}
+
+type typedAttachFuture[O any] struct {
+	AttachFuture
+}
+
+func (t typedAttachFuture[O]) Response() (output O, err error) {
+	err = t.AttachFuture.Response(&output)
+	return
+}
+
+// AttachInvocationAs helper function to treat the response of an attached invocation as a particular type.
+func AttachInvocationAs[O any](ctx Context, invocationId string, options ...options.AttachOption) (TypedAttachFuture[O], error) {
+	fut, err := ctx.AttachInvocation(invocationId, options...)
+	if err != nil {
+		return nil, err
+	}
+	return typedAttachFuture[O]{fut}, nil
+}
+
+// TypedInvocationOutputFuture is an extension of [InvocationOutputFuture] which returns typed outputs instead of
+// accepting a pointer
+type TypedInvocationOutputFuture[O any] interface {
+	// Output blocks on the output of the invocation. If the invocation had not completed, ok is false; otherwise
+	// its response is returned, or the associated error.
+	Output() (output O, ok bool, err error)
+	Selectable
+}
+
+type typedInvocationOutputFuture[O any] struct {
+	InvocationOutputFuture
+}
+
+func (t typedInvocationOutputFuture[O]) Output() (output O, ok bool, err error) {
+	ok, err = t.InvocationOutputFuture.Output(&output)
+	return
+}
+
+// GetInvocationOutputAs helper function to treat the output of an invocation as a particular type.
+func GetInvocationOutputAs[O any](ctx Context, invocationId string, options ...options.AttachOption) (TypedInvocationOutputFuture[O], error) {
+	fut, err := ctx.GetInvocationOutput(invocationId, options...)
+	if err != nil {
+		return nil, err
+	}
+	return typedInvocationOutputFuture[O]{fut}, nil
+}
+
+// TypedDurablePromise is an extension of [DurablePromise] which returns typed responses instead of accepting a pointer
+type TypedDurablePromise[T any] interface {
+	// Result blocks on receiving the result of the promise, returning the value it was
+	// resolved with or otherwise returning the error it was rejected with.
+	// It is *not* safe to call this in a goroutine - use Context.Select if you
+	// want to wait on multiple results at once.
+	Result() (T, error)
+	// Peek returns the result of the promise if it has already been completed, without blocking
+	// on it otherwise. ok is false if the promise has not been completed yet.
+	Peek() (output T, ok bool, err error)
+	// Resolve completes the promise with a value
+	Resolve(value T) error
+	// Reject completes the promise with a failure
+	Reject(reason error) error
+	Selectable
+}
+
+type typedDurablePromise[T any] struct {
+	DurablePromise
+}
+
+func (t typedDurablePromise[T]) Result() (output T, err error) {
+	err = t.DurablePromise.Result(&output)
+	return
+}
+
+func (t typedDurablePromise[T]) Peek() (output T, ok bool, err error) {
+	ok, err = t.DurablePromise.Peek(&output)
+	return
+}
+
+func (t typedDurablePromise[T]) Resolve(value T) error {
+	return t.DurablePromise.Resolve(value)
+}
+
+// PromiseAs helper function to treat [DurablePromise] results as a particular type.
+func PromiseAs[T any](ctx WorkflowSharedContext, name string, options ...options.PromiseOption) TypedDurablePromise[T] {
+	return typedDurablePromise[T]{ctx.Promise(name, options...)}
+}
+
 // TypedCallClient is an extension of [CallClient] which returns typed responses instead of accepting a pointer
 type TypedCallClient[O any] interface {
 	// RequestFuture makes a call and returns a handle on a future response
@@ -82,6 +250,7 @@ type TypedResponseFuture[O any] interface {
 	// It is *not* safe to call this in a goroutine - use Context.Select if you
 	// want to wait on multiple results at once.
 	Response() (O, error)
+	InvocationHandle
 	Selectable
 }
 
@@ -98,3 +267,64 @@ func (t typedResponseFuture[O]) Response() (output O, err error) {
 func CallAs[O any](client CallClient) TypedCallClient[O] {
 	return typedCallClient[O]{client}
 }
+
+// TypedSendClient is a typed extension of [SendClient] for a single handler, which accepts the input type of the
+// handler
+type TypedSendClient[I any] interface {
+	// Send makes a one-way call which is executed in the background
+	Send(input I, delay time.Duration) error
+	// SendAt makes a one-way call which is executed in the background at the time at, or immediately if at has
+	// already passed
+	SendAt(input I, at time.Time) error
+	// SendWithHandle makes a one-way call like Send, returning a handle on the invocation
+	SendWithHandle(input I, delay time.Duration) (InvocationHandle, error)
+}
+
+type typedSendClient[I any] struct {
+	inner SendClient
+}
+
+func (t typedSendClient[I]) Send(input I, delay time.Duration) error {
+	return t.inner.Send(input, delay)
+}
+
+func (t typedSendClient[I]) SendAt(input I, at time.Time) error {
+	return t.inner.SendAt(input, at)
+}
+
+func (t typedSendClient[I]) SendWithHandle(input I, delay time.Duration) (InvocationHandle, error) {
+	return t.inner.SendWithHandle(input, delay)
+}
+
+// SendAs helper function to accept typed inputs in a [SendClient], for handlers which are only sent one-way calls
+func SendAs[I any](client SendClient) TypedSendClient[I] {
+	return typedSendClient[I]{client}
+}
+
+// TypedClient is a typed extension of [CallClient] for a single handler, which accepts the input type of the handler
+// and returns its output type. Typed clients for whole services are generated by protoc-gen-go-restate and restate-gen.
+type TypedClient[I any, O any] interface {
+	// RequestFuture makes a call and returns a handle on a future response
+	RequestFuture(input I) (TypedResponseFuture[O], error)
+	// Request makes a call and blocks on getting the response
+	Request(input I) (O, error)
+	TypedSendClient[I]
+}
+
+type typedClient[I any, O any] struct {
+	inner typedCallClient[O]
+	typedSendClient[I]
+}
+
+func (t typedClient[I, O]) RequestFuture(input I) (TypedResponseFuture[O], error) {
+	return t.inner.RequestFuture(input)
+}
+
+func (t typedClient[I, O]) Request(input I) (O, error) {
+	return t.inner.Request(input)
+}
+
+// ClientAs helper function to accept typed inputs and return typed responses from a [CallClient]
+func ClientAs[I any, O any](client CallClient) TypedClient[I, O] {
+	return typedClient[I, O]{typedCallClient[O]{client}, typedSendClient[I]{client}}
+}
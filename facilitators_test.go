@@ -0,0 +1,46 @@
+package restate_test
+
+import (
+	"fmt"
+	"testing"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkState(t *testing.T) {
+	handler := restate.NewObjectHandler(func(ctx restate.ObjectContext, _ restate.Void) (map[string]int, error) {
+		cart, err := restate.GetPrefix[int](ctx, "cart/")
+		if err != nil {
+			return nil, err
+		}
+		if err := restate.SetMany(ctx, map[string]int{"total/b": 2, "total/a": 1}); err != nil {
+			return nil, err
+		}
+		if cleared := restate.ClearPrefix(ctx, "cart/"); len(cleared) != len(cart) {
+			return nil, fmt.Errorf("expected %d keys to be cleared, got %v", len(cart), cleared)
+		}
+		// the cleared key is skipped even if the error is wrapped
+		return restate.GetMany[int](wrappingReader{ctx}, []string{"total/a", "total/b", "cart/apple"})
+	})
+	restate.NewObject("Cart").Handler("checkout", handler)
+
+	runtime := restatetest.Runtime{
+		Key: "cart",
+		State: map[string][]byte{
+			"cart/apple": restatetest.JSON(t, 3),
+			"cart/pear":  restatetest.JSON(t, 4),
+			"other":      restatetest.JSON(t, 5),
+		},
+	}
+
+	result := runtime.Complete(t, handler, nil)
+	require.NoError(t, result.TerminalError)
+	require.Equal(t, restatetest.JSON(t, map[string]int{"total/a": 1, "total/b": 2}), result.Output)
+	require.Equal(t, map[string][]byte{
+		"other":   restatetest.JSON(t, 5),
+		"total/a": restatetest.JSON(t, 1),
+		"total/b": restatetest.JSON(t, 2),
+	}, result.State)
+}
@@ -0,0 +1,185 @@
+//
+// Copyright (c) 2023-2024 - Restate Software, Inc., Restate GmbH
+//
+// This file is part of the Restate SDK for Go,
+// which is released under the MIT license.
+//
+// You can find a copy of the license in file LICENSE in the root
+// directory of this repository or package, or at
+// https://github.com/restatedev/sdk-go/blob/main/LICENSE
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        (unknown)
+// source: proto/dev/restate/sdk/go.proto
+
+package sdk
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ServiceType int32
+
+const (
+	ServiceType_SERVICE        ServiceType = 0
+	ServiceType_VIRTUAL_OBJECT ServiceType = 1
+	ServiceType_WORKFLOW       ServiceType = 2
+)
+
+// Enum value maps for ServiceType.
+var (
+	ServiceType_name = map[int32]string{
+		0: "SERVICE",
+		1: "VIRTUAL_OBJECT",
+		2: "WORKFLOW",
+	}
+	ServiceType_value = map[string]int32{
+		"SERVICE":        0,
+		"VIRTUAL_OBJECT": 1,
+		"WORKFLOW":       2,
+	}
+)
+
+func (x ServiceType) Enum() *ServiceType {
+	p := new(ServiceType)
+	*p = x
+	return p
+}
+
+func (x ServiceType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServiceType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dev_restate_sdk_go_proto_enumTypes[0].Descriptor()
+}
+
+func (ServiceType) Type() protoreflect.EnumType {
+	return &file_proto_dev_restate_sdk_go_proto_enumTypes[0]
+}
+
+func (x ServiceType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServiceType.Descriptor instead.
+func (ServiceType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dev_restate_sdk_go_proto_rawDescGZIP(), []int{0}
+}
+
+var file_proto_dev_restate_sdk_go_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.ServiceOptions)(nil),
+		ExtensionType: (*ServiceType)(nil),
+		Field:         2051,
+		Name:          "dev.restate.sdk.go.service_type",
+		Tag:           "varint,2051,opt,name=service_type,enum=dev.restate.sdk.go.ServiceType",
+		Filename:      "proto/dev/restate/sdk/go.proto",
+	},
+}
+
+// Extension fields to descriptorpb.ServiceOptions.
+var (
+	// optional dev.restate.sdk.go.ServiceType service_type = 2051;
+	E_ServiceType = &file_proto_dev_restate_sdk_go_proto_extTypes[0]
+)
+
+var File_proto_dev_restate_sdk_go_proto protoreflect.FileDescriptor
+
+var file_proto_dev_restate_sdk_go_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x65, 0x76, 0x2f, 0x72, 0x65, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x2f, 0x73, 0x64, 0x6b, 0x2f, 0x67, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x12, 0x64, 0x65, 0x76, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x64,
+	0x6b, 0x2e, 0x67, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2a, 0x3c, 0x0a, 0x0b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x45, 0x52, 0x56, 0x49, 0x43, 0x45,
+	0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x56, 0x49, 0x52, 0x54, 0x55, 0x41, 0x4c, 0x5f, 0x4f, 0x42,
+	0x4a, 0x45, 0x43, 0x54, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x57, 0x4f, 0x52, 0x4b, 0x46, 0x4c,
+	0x4f, 0x57, 0x10, 0x02, 0x3a, 0x64, 0x0a, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x83, 0x10, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x64,
+	0x65, 0x76, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x73, 0x64, 0x6b, 0x2e, 0x67,
+	0x6f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x42, 0xcb, 0x01, 0x0a, 0x16, 0x63,
+	0x6f, 0x6d, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x73,
+	0x64, 0x6b, 0x2e, 0x67, 0x6f, 0x42, 0x07, 0x47, 0x6f, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01,
+	0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x65, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x64, 0x65, 0x76, 0x2f, 0x73, 0x64, 0x6b, 0x2d, 0x67, 0x6f, 0x2f, 0x67,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64,
+	0x65, 0x76, 0x2f, 0x72, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2f, 0x73, 0x64, 0x6b, 0xa2, 0x02,
+	0x04, 0x44, 0x52, 0x53, 0x47, 0xaa, 0x02, 0x12, 0x44, 0x65, 0x76, 0x2e, 0x52, 0x65, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x2e, 0x53, 0x64, 0x6b, 0x2e, 0x47, 0x6f, 0xca, 0x02, 0x12, 0x44, 0x65, 0x76,
+	0x5c, 0x52, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5c, 0x53, 0x64, 0x6b, 0x5c, 0x47, 0x6f, 0xe2,
+	0x02, 0x1e, 0x44, 0x65, 0x76, 0x5c, 0x52, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5c, 0x53, 0x64,
+	0x6b, 0x5c, 0x47, 0x6f, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0xea, 0x02, 0x15, 0x44, 0x65, 0x76, 0x3a, 0x3a, 0x52, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x3a,
+	0x3a, 0x53, 0x64, 0x6b, 0x3a, 0x3a, 0x47, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_dev_restate_sdk_go_proto_rawDescOnce sync.Once
+	file_proto_dev_restate_sdk_go_proto_rawDescData = file_proto_dev_restate_sdk_go_proto_rawDesc
+)
+
+func file_proto_dev_restate_sdk_go_proto_rawDescGZIP() []byte {
+	file_proto_dev_restate_sdk_go_proto_rawDescOnce.Do(func() {
+		file_proto_dev_restate_sdk_go_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_dev_restate_sdk_go_proto_rawDescData)
+	})
+	return file_proto_dev_restate_sdk_go_proto_rawDescData
+}
+
+var file_proto_dev_restate_sdk_go_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_dev_restate_sdk_go_proto_goTypes = []interface{}{
+	(ServiceType)(0),                    // 0: dev.restate.sdk.go.ServiceType
+	(*descriptorpb.ServiceOptions)(nil), // 1: google.protobuf.ServiceOptions
+}
+var file_proto_dev_restate_sdk_go_proto_depIdxs = []int32{
+	1, // 0: dev.restate.sdk.go.service_type:extendee -> google.protobuf.ServiceOptions
+	0, // 1: dev.restate.sdk.go.service_type:type_name -> dev.restate.sdk.go.ServiceType
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_proto_dev_restate_sdk_go_proto_init() }
+func file_proto_dev_restate_sdk_go_proto_init() {
+	if File_proto_dev_restate_sdk_go_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_dev_restate_sdk_go_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_dev_restate_sdk_go_proto_goTypes,
+		DependencyIndexes: file_proto_dev_restate_sdk_go_proto_depIdxs,
+		EnumInfos:         file_proto_dev_restate_sdk_go_proto_enumTypes,
+		ExtensionInfos:    file_proto_dev_restate_sdk_go_proto_extTypes,
+	}.Build()
+	File_proto_dev_restate_sdk_go_proto = out.File
+	file_proto_dev_restate_sdk_go_proto_rawDesc = nil
+	file_proto_dev_restate_sdk_go_proto_goTypes = nil
+	file_proto_dev_restate_sdk_go_proto_depIdxs = nil
+}
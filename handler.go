@@ -32,6 +32,13 @@ type ServiceHandler interface {
 	Handler
 }
 
+// WorkflowHandler is the required set of methods for a Workflow handler.
+type WorkflowHandler interface {
+	Call(ctx WorkflowContext, request []byte) (output []byte, err error)
+	getOptions() *options.WorkflowHandlerOptions
+	Handler
+}
+
 // Handler is implemented by all Restate handlers
 type Handler interface {
 	sealed()
@@ -49,6 +56,12 @@ type ObjectHandlerFn[I any, O any] func(ctx ObjectContext, input I) (O, error)
 // ObjectHandlerFn is the signature for a Virtual Object shared-mode handler function
 type ObjectSharedHandlerFn[I any, O any] func(ctx ObjectSharedContext, input I) (O, error)
 
+// WorkflowHandlerFn is the signature for the run handler function of a Workflow
+type WorkflowHandlerFn[I any, O any] func(ctx WorkflowContext, input I) (O, error)
+
+// WorkflowSharedHandlerFn is the signature for a Workflow shared-mode handler function
+type WorkflowSharedHandlerFn[I any, O any] func(ctx WorkflowSharedContext, input I) (O, error)
+
 type serviceHandler[I any, O any] struct {
 	fn      ServiceHandlerFn[I, O]
 	options options.ServiceHandlerOptions
@@ -69,6 +82,13 @@ func NewServiceHandler[I any, O any](fn ServiceHandlerFn[I, O], opts ...options.
 }
 
 func (h *serviceHandler[I, O]) Call(ctx Context, bytes []byte) ([]byte, error) {
+	if len(h.options.Middleware) == 0 {
+		return h.call(ctx, bytes)
+	}
+	return chainOptions(h.call, h.options.Middleware)(ctx, bytes)
+}
+
+func (h *serviceHandler[I, O]) call(ctx Context, bytes []byte) ([]byte, error) {
 	var input I
 	if err := encoding.Unmarshal(h.options.Codec, bytes, &input); err != nil {
 		return nil, TerminalError(fmt.Errorf("request could not be decoded into handler input type: %w", err), http.StatusBadRequest)
@@ -149,6 +169,15 @@ func NewObjectSharedHandler[I any, O any](fn ObjectSharedHandlerFn[I, O], opts .
 }
 
 func (h *objectHandler[I, O]) Call(ctx ObjectContext, bytes []byte) ([]byte, error) {
+	if len(h.options.Middleware) == 0 {
+		return h.call(ctx, bytes)
+	}
+	return chainOptions(func(ctx Context, bytes []byte) ([]byte, error) {
+		return h.call(internal.ContextAs[ObjectContext](ctx), bytes)
+	}, h.options.Middleware)(ctx, bytes)
+}
+
+func (h *objectHandler[I, O]) call(ctx ObjectContext, bytes []byte) ([]byte, error) {
 	var input I
 	if err := encoding.Unmarshal(h.options.Codec, bytes, &input); err != nil {
 		return nil, TerminalError(fmt.Errorf("request could not be decoded into handler input type: %w", err), http.StatusBadRequest)
@@ -199,3 +228,104 @@ func (h *objectHandler[I, O]) HandlerType() *internal.ServiceHandlerType {
 }
 
 func (h *objectHandler[I, O]) sealed() {}
+
+type workflowHandler[I any, O any] struct {
+	// only one of runFn or sharedFn should be set, as indicated by handlerType
+	runFn       WorkflowHandlerFn[I, O]
+	sharedFn    WorkflowSharedHandlerFn[I, O]
+	options     options.WorkflowHandlerOptions
+	handlerType internal.ServiceHandlerType
+}
+
+var _ WorkflowHandler = (*workflowHandler[struct{}, struct{}])(nil)
+
+// NewWorkflowHandler converts a function of signature [WorkflowHandlerFn] into the run handler of a Workflow.
+// The handler will have access to a full [WorkflowContext] which may mutate state. It is executed exactly once
+// per workflow ID.
+func NewWorkflowHandler[I any, O any](fn WorkflowHandlerFn[I, O], opts ...options.WorkflowHandlerOption) *workflowHandler[I, O] {
+	o := options.WorkflowHandlerOptions{}
+	for _, opt := range opts {
+		opt.BeforeWorkflowHandler(&o)
+	}
+	return &workflowHandler[I, O]{
+		runFn:       fn,
+		options:     o,
+		handlerType: internal.ServiceHandlerType_WORKFLOW,
+	}
+}
+
+// NewWorkflowSharedHandler converts a function of signature [WorkflowSharedHandlerFn] into a shared-mode handler on a Workflow.
+// The handler will only have access to a [WorkflowSharedContext] which can only read a snapshot of state, but
+// may interact with the durable promises of the workflow.
+func NewWorkflowSharedHandler[I any, O any](fn WorkflowSharedHandlerFn[I, O], opts ...options.WorkflowHandlerOption) *workflowHandler[I, O] {
+	o := options.WorkflowHandlerOptions{}
+	for _, opt := range opts {
+		opt.BeforeWorkflowHandler(&o)
+	}
+	return &workflowHandler[I, O]{
+		sharedFn:    fn,
+		options:     o,
+		handlerType: internal.ServiceHandlerType_SHARED,
+	}
+}
+
+func (h *workflowHandler[I, O]) Call(ctx WorkflowContext, bytes []byte) ([]byte, error) {
+	if len(h.options.Middleware) == 0 {
+		return h.call(ctx, bytes)
+	}
+	return chainOptions(func(ctx Context, bytes []byte) ([]byte, error) {
+		return h.call(internal.ContextAs[WorkflowContext](ctx), bytes)
+	}, h.options.Middleware)(ctx, bytes)
+}
+
+func (h *workflowHandler[I, O]) call(ctx WorkflowContext, bytes []byte) ([]byte, error) {
+	var input I
+	if err := encoding.Unmarshal(h.options.Codec, bytes, &input); err != nil {
+		return nil, TerminalError(fmt.Errorf("request could not be decoded into handler input type: %w", err), http.StatusBadRequest)
+	}
+
+	var output O
+	var err error
+	switch h.handlerType {
+	case internal.ServiceHandlerType_WORKFLOW:
+		output, err = h.runFn(
+			ctx,
+			input,
+		)
+	case internal.ServiceHandlerType_SHARED:
+		output, err = h.sharedFn(
+			ctx,
+			input,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err = encoding.Marshal(h.options.Codec, output)
+	if err != nil {
+		return nil, TerminalError(fmt.Errorf("failed to serialize output: %w", err))
+	}
+
+	return bytes, nil
+}
+
+func (h *workflowHandler[I, O]) InputPayload() *encoding.InputPayload {
+	var i I
+	return encoding.InputPayloadFor(h.options.Codec, i)
+}
+
+func (h *workflowHandler[I, O]) OutputPayload() *encoding.OutputPayload {
+	var o O
+	return encoding.OutputPayloadFor(h.options.Codec, o)
+}
+
+func (h *workflowHandler[I, O]) getOptions() *options.WorkflowHandlerOptions {
+	return &h.options
+}
+
+func (h *workflowHandler[I, O]) HandlerType() *internal.ServiceHandlerType {
+	return &h.handlerType
+}
+
+func (h *workflowHandler[I, O]) sealed() {}
@@ -0,0 +1,56 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/restatedev/sdk-go/encoding"
+	"github.com/restatedev/sdk-go/internal/futures"
+	"github.com/restatedev/sdk-go/internal/options"
+)
+
+// ResolveAwakeable completes the awakeable with the provided ID with a value, waking up the handler
+// that is waiting on it. The ID is the one returned by Awakeable.Id within the handler.
+func (c *Client) ResolveAwakeable(ctx context.Context, id string, value any, opts ...options.IngressRequestOption) error {
+	if err := validateAwakeableID(id); err != nil {
+		return err
+	}
+
+	o := options.IngressRequestOptions{}
+	for _, opt := range opts {
+		opt.BeforeIngressRequest(&o)
+	}
+	if o.Codec == nil {
+		o.Codec = encoding.JSONCodec
+	}
+
+	_, err := c.do(ctx, http.MethodPost, escapePath("restate", "awakeables", id, "resolve"), nil, o, value)
+	return err
+}
+
+// RejectAwakeable completes the awakeable with the provided ID with a failure, which the handler
+// that is waiting on it receives as a terminal error.
+func (c *Client) RejectAwakeable(ctx context.Context, id string, reason error, opts ...options.IngressRequestOption) error {
+	if err := validateAwakeableID(id); err != nil {
+		return err
+	}
+
+	o := options.IngressRequestOptions{}
+	for _, opt := range opts {
+		opt.BeforeIngressRequest(&o)
+	}
+	// the reason is always sent as plain text
+	o.Codec = encoding.BinaryCodec
+
+	_, err := c.do(ctx, http.MethodPost, escapePath("restate", "awakeables", id, "reject"), nil, o, []byte(reason.Error()))
+	return err
+}
+
+func validateAwakeableID(id string) error {
+	if !strings.HasPrefix(id, futures.AWAKEABLE_IDENTIFIER_PREFIX) {
+		return fmt.Errorf("invalid awakeable id %q: must start with %s", id, futures.AWAKEABLE_IDENTIFIER_PREFIX)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+// Package ingress provides a client for invoking Restate services through the Restate ingress,
+// for use outside of a handler, eg from an API gateway or a CLI.
+package ingress
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/encoding"
+	"github.com/restatedev/sdk-go/internal/options"
+)
+
+// Client is a client for the Restate ingress
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	headers    map[string]string
+}
+
+// NewClient returns a client for the Restate ingress at baseURL, eg http://localhost:8080
+func NewClient(baseURL string, opts ...options.IngressClientOption) *Client {
+	o := options.IngressClientOptions{}
+	for _, opt := range opts {
+		opt.BeforeIngressClient(&o)
+	}
+	if o.HTTPClient == nil {
+		o.HTTPClient = http.DefaultClient
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: o.HTTPClient,
+		headers:    o.Headers,
+	}
+}
+
+// Invocation is the response of the ingress to a one-way call
+type Invocation struct {
+	ID string `json:"invocationId"`
+	// Status is Accepted, or PreviouslyAccepted if an invocation with the same idempotency key already exists
+	Status string `json:"status"`
+}
+
+// Requester makes requests to a particular service/key/handler tuple through the ingress
+type Requester[I any, O any] interface {
+	// Request makes a call and blocks on the response
+	Request(ctx context.Context, input I, opts ...options.IngressRequestOption) (O, error)
+	// Send makes a one-way call, returning once the invocation has been accepted by Restate
+	Send(ctx context.Context, input I, opts ...options.IngressSendOption) (Invocation, error)
+}
+
+// Service returns a [Requester] for a handler of a Service
+func Service[I any, O any](client *Client, service, handler string, opts ...options.IngressRequestOption) Requester[I, O] {
+	return newRequester[I, O](client, opts, service, handler)
+}
+
+// Object returns a [Requester] for a handler of a Virtual Object
+func Object[I any, O any](client *Client, object, key, handler string, opts ...options.IngressRequestOption) Requester[I, O] {
+	return newRequester[I, O](client, opts, object, key, handler)
+}
+
+// Workflow returns a [Requester] for a handler of a Workflow
+func Workflow[I any, O any](client *Client, workflow, key, handler string, opts ...options.IngressRequestOption) Requester[I, O] {
+	return newRequester[I, O](client, opts, workflow, key, handler)
+}
+
+// Call makes a request to a handler of a Service and blocks on the response
+func Call[I any, O any](ctx context.Context, client *Client, service, handler string, input I, opts ...options.IngressRequestOption) (O, error) {
+	return Service[I, O](client, service, handler).Request(ctx, input, opts...)
+}
+
+// Send makes a one-way call to a handler of a Service
+func Send[I any](ctx context.Context, client *Client, service, handler string, input I, opts ...options.IngressSendOption) (Invocation, error) {
+	return Service[I, restate.Void](client, service, handler).Send(ctx, input, opts...)
+}
+
+// CallObject makes a request to a handler of a Virtual Object and blocks on the response
+func CallObject[I any, O any](ctx context.Context, client *Client, object, key, handler string, input I, opts ...options.IngressRequestOption) (O, error) {
+	return Object[I, O](client, object, key, handler).Request(ctx, input, opts...)
+}
+
+// SendObject makes a one-way call to a handler of a Virtual Object
+func SendObject[I any](ctx context.Context, client *Client, object, key, handler string, input I, opts ...options.IngressSendOption) (Invocation, error) {
+	return Object[I, restate.Void](client, object, key, handler).Send(ctx, input, opts...)
+}
+
+type requester[I any, O any] struct {
+	client  *Client
+	path    string
+	options options.IngressRequestOptions
+}
+
+func newRequester[I any, O any](client *Client, opts []options.IngressRequestOption, segments ...string) *requester[I, O] {
+	o := options.IngressRequestOptions{}
+	for _, opt := range opts {
+		opt.BeforeIngressRequest(&o)
+	}
+
+	return &requester[I, O]{client, escapePath(segments...), o}
+}
+
+func (r *requester[I, O]) Request(ctx context.Context, input I, opts ...options.IngressRequestOption) (output O, err error) {
+	o := r.options
+	for _, opt := range opts {
+		opt.BeforeIngressRequest(&o)
+	}
+	if o.Codec == nil {
+		o.Codec = encoding.JSONCodec
+	}
+
+	body, err := r.client.do(ctx, http.MethodPost, r.path, nil, o, input)
+	if err != nil {
+		return output, err
+	}
+
+	if err := encoding.Unmarshal(o.Codec, body, &output); err != nil {
+		return output, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return output, nil
+}
+
+func (r *requester[I, O]) Send(ctx context.Context, input I, opts ...options.IngressSendOption) (invocation Invocation, err error) {
+	o := options.IngressSendOptions{IngressRequestOptions: r.options}
+	for _, opt := range opts {
+		opt.BeforeIngressSend(&o)
+	}
+	if o.Codec == nil {
+		o.Codec = encoding.JSONCodec
+	}
+
+	var query url.Values
+	if o.Delay > 0 {
+		query = url.Values{"delay": {fmt.Sprintf("%dms", o.Delay.Milliseconds())}}
+	}
+
+	body, err := r.client.do(ctx, http.MethodPost, r.path+"/send", query, o.IngressRequestOptions, input)
+	if err != nil {
+		return invocation, err
+	}
+
+	if err := json.Unmarshal(body, &invocation); err != nil {
+		return invocation, fmt.Errorf("failed to unmarshal send response: %w", err)
+	}
+
+	return invocation, nil
+}
+
+// Attach blocks on the result of the invocation with the provided ID, which is usually returned by a send
+func Attach[O any](ctx context.Context, client *Client, invocationID string, opts ...options.IngressRequestOption) (output O, err error) {
+	o := options.IngressRequestOptions{}
+	for _, opt := range opts {
+		opt.BeforeIngressRequest(&o)
+	}
+	if o.Codec == nil {
+		o.Codec = encoding.JSONCodec
+	}
+
+	body, err := client.do(ctx, http.MethodGet, escapePath("restate", "invocation", invocationID, "attach"), nil, o, restate.Void{})
+	if err != nil {
+		return output, err
+	}
+
+	if err := encoding.Unmarshal(o.Codec, body, &output); err != nil {
+		return output, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return output, nil
+}
+
+func escapePath(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i := range segments {
+		escaped[i] = url.PathEscape(segments[i])
+	}
+	return "/" + strings.Join(escaped, "/")
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, opts options.IngressRequestOptions, input any) ([]byte, error) {
+	data, err := encoding.Marshal(opts.Codec, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var body io.Reader
+	if len(data) > 0 {
+		body = bytes.NewReader(data)
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if payloadCodec, ok := opts.Codec.(encoding.PayloadCodec); ok && len(data) > 0 {
+		if contentType := encoding.InputPayloadFor(payloadCodec, input).ContentType; contentType != nil {
+			req.Header.Set("Content-Type", *contentType)
+		}
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	for k, v := range opts.Headers {
+		req.Header.Set(k, v)
+	}
+	if opts.IdempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", opts.IdempotencyKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var errResp errorResponse
+		if err := json.Unmarshal(respBody, &errResp); err != nil || errResp.Message == "" {
+			errResp.Message = strings.TrimSpace(string(respBody))
+		}
+		if errResp.Message == "" {
+			errResp.Message = http.StatusText(resp.StatusCode)
+		}
+		return nil, restate.WithErrorCode(fmt.Errorf("%s", errResp.Message), restate.Code(resp.StatusCode))
+	}
+
+	return respBody, nil
+}
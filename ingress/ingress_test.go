@@ -0,0 +1,126 @@
+package ingress_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/ingress"
+	"github.com/stretchr/testify/require"
+)
+
+type request struct {
+	method string
+	path   string
+	query  string
+	header http.Header
+	body   string
+}
+
+func newServer(t *testing.T, status int, response string) (*ingress.Client, <-chan request) {
+	t.Helper()
+	requests := make(chan request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests <- request{r.Method, r.URL.EscapedPath(), r.URL.RawQuery, r.Header, string(body)}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return ingress.NewClient(server.URL+"/", restate.WithHeaders(map[string]string{"Authorization": "Bearer token"})), requests
+}
+
+func TestRequest(t *testing.T) {
+	client, requests := newServer(t, http.StatusOK, `"hello bob"`)
+
+	output, err := ingress.Call[string, string](context.Background(), client, "Greeter", "greet", "bob", ingress.WithIdempotencyKey("my-key"))
+	require.NoError(t, err)
+	require.Equal(t, "hello bob", output)
+
+	req := <-requests
+	require.Equal(t, http.MethodPost, req.method)
+	require.Equal(t, "/Greeter/greet", req.path)
+	require.Equal(t, `"bob"`, req.body)
+	require.Equal(t, "application/json", req.header.Get("Content-Type"))
+	require.Equal(t, "my-key", req.header.Get("Idempotency-Key"))
+	require.Equal(t, "Bearer token", req.header.Get("Authorization"))
+}
+
+func TestObjectRequestVoid(t *testing.T) {
+	client, requests := newServer(t, http.StatusOK, ``)
+
+	_, err := ingress.Object[restate.Void, restate.Void](client, "Counter", "my key", "reset").Request(context.Background(), restate.Void{})
+	require.NoError(t, err)
+
+	req := <-requests
+	require.Equal(t, "/Counter/my%20key/reset", req.path)
+	require.Empty(t, req.body)
+	require.Empty(t, req.header.Get("Content-Type"))
+}
+
+func TestSend(t *testing.T) {
+	client, requests := newServer(t, http.StatusAccepted, `{"invocationId":"inv_1","status":"Accepted"}`)
+
+	invocation, err := ingress.SendObject(context.Background(), client, "Counter", "counter-1", "add", 5, ingress.WithDelay(90*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, ingress.Invocation{ID: "inv_1", Status: "Accepted"}, invocation)
+
+	req := <-requests
+	require.Equal(t, "/Counter/counter-1/add/send", req.path)
+	require.Equal(t, "delay=90000ms", req.query)
+	require.Equal(t, "5", req.body)
+}
+
+func TestAttach(t *testing.T) {
+	client, requests := newServer(t, http.StatusOK, `42`)
+
+	output, err := ingress.Attach[int](context.Background(), client, "inv_1")
+	require.NoError(t, err)
+	require.Equal(t, 42, output)
+
+	req := <-requests
+	require.Equal(t, http.MethodGet, req.method)
+	require.Equal(t, "/restate/invocation/inv_1/attach", req.path)
+}
+
+func TestError(t *testing.T) {
+	client, _ := newServer(t, http.StatusNotFound, `{"message":"service not found"}`)
+
+	_, err := ingress.Call[string, string](context.Background(), client, "Missing", "greet", "bob")
+	require.EqualError(t, err, "[404] service not found")
+	require.EqualValues(t, 404, restate.ErrorCode(err))
+}
+
+func TestAwakeable(t *testing.T) {
+	t.Run("resolve", func(t *testing.T) {
+		client, requests := newServer(t, http.StatusAccepted, ``)
+
+		require.NoError(t, client.ResolveAwakeable(context.Background(), "prom_1abc", "done"))
+
+		req := <-requests
+		require.Equal(t, "/restate/awakeables/prom_1abc/resolve", req.path)
+		require.Equal(t, `"done"`, req.body)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		client, requests := newServer(t, http.StatusAccepted, ``)
+
+		require.NoError(t, client.RejectAwakeable(context.Background(), "prom_1abc", fmt.Errorf("failed")))
+
+		req := <-requests
+		require.Equal(t, "/restate/awakeables/prom_1abc/reject", req.path)
+		require.Equal(t, `failed`, req.body)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		client, _ := newServer(t, http.StatusAccepted, ``)
+
+		require.Error(t, client.ResolveAwakeable(context.Background(), "not-an-awakeable", "done"))
+	})
+}
@@ -0,0 +1,58 @@
+package ingress
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/restatedev/sdk-go/internal/options"
+)
+
+type withHTTPClient struct {
+	client *http.Client
+}
+
+var _ options.IngressClientOption = withHTTPClient{}
+
+func (w withHTTPClient) BeforeIngressClient(opts *options.IngressClientOptions) {
+	opts.HTTPClient = w.client
+}
+
+// WithHTTPClient is an option to specify the [http.Client] used to make requests to the ingress
+func WithHTTPClient(client *http.Client) withHTTPClient {
+	return withHTTPClient{client}
+}
+
+type withIdempotencyKey struct {
+	key string
+}
+
+var _ options.IngressRequestOption = withIdempotencyKey{}
+var _ options.IngressSendOption = withIdempotencyKey{}
+
+func (w withIdempotencyKey) BeforeIngressRequest(opts *options.IngressRequestOptions) {
+	opts.IdempotencyKey = w.key
+}
+func (w withIdempotencyKey) BeforeIngressSend(opts *options.IngressSendOptions) {
+	opts.IdempotencyKey = w.key
+}
+
+// WithIdempotencyKey is an option to specify the idempotency key of a request or send. Restate will
+// deduplicate invocations of the same handler with the same key, returning the result of the first one.
+func WithIdempotencyKey(key string) withIdempotencyKey {
+	return withIdempotencyKey{key}
+}
+
+type withDelay struct {
+	delay time.Duration
+}
+
+var _ options.IngressSendOption = withDelay{}
+
+func (w withDelay) BeforeIngressSend(opts *options.IngressSendOptions) {
+	opts.Delay = w.delay
+}
+
+// WithDelay is an option to specify that a send should only be executed after the provided delay
+func WithDelay(delay time.Duration) withDelay {
+	return withDelay{delay}
+}
@@ -0,0 +1,196 @@
+// Package codegen emits typed clients for Restate services. It is shared by protoc-gen-go-restate,
+// which discovers services from proto definitions, and restate-gen, which discovers them from Go structs.
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+const (
+	SDKImportPath     = "github.com/restatedev/sdk-go"
+	IngressImportPath = "github.com/restatedev/sdk-go/ingress"
+)
+
+type ServiceType int
+
+const (
+	ServiceTypeService ServiceType = iota
+	ServiceTypeObject
+	ServiceTypeWorkflow
+)
+
+// Service describes a service to generate clients for
+type Service struct {
+	// GoName is the prefix of the generated types, eg Checkout produces CheckoutClient
+	GoName string
+	// Name is the name the service is registered with in Restate
+	Name     string
+	Type     ServiceType
+	Handlers []Handler
+	// Options are Go expressions, already qualified, that are passed as options to every call
+	Options []string
+}
+
+// Handler describes a single handler of a service
+type Handler struct {
+	// GoName is the name of the generated client method
+	GoName string
+	// Name is the name the handler is registered with in Restate
+	Name string
+	// Input and Output are the Go types of the handler, already qualified
+	Input  string
+	Output string
+}
+
+// Qualifier returns the expression used to refer to the exported name in the package at importPath,
+// importing the package if necessary
+type Qualifier func(importPath, name string) string
+
+// Write emits the typed handler and ingress clients for services into w
+func Write(w io.Writer, qualify Qualifier, services []Service) error {
+	for _, service := range services {
+		if err := clientTemplate.Execute(w, newServiceData(qualify, service)); err != nil {
+			return fmt.Errorf("failed to generate clients for %s: %w", service.Name, err)
+		}
+	}
+	return nil
+}
+
+type serviceData struct {
+	Service
+	Keyed bool
+	Kind  string
+	// unexported names of the client implementations
+	Client        string
+	IngressClient string
+	// qualified references to the SDK
+	Context   string
+	Typed     string
+	ClientAs  string
+	Ingress   string
+	Requester string
+	Requests  string
+	Accessor  string
+	Options   string
+}
+
+func newServiceData(qualify Qualifier, service Service) serviceData {
+	data := serviceData{
+		Service:       service,
+		Keyed:         service.Type != ServiceTypeService,
+		Client:        unexport(service.GoName) + "Client",
+		IngressClient: unexport(service.GoName) + "IngressClient",
+		Context:       qualify(SDKImportPath, "Context"),
+		Typed:         qualify(SDKImportPath, "TypedClient"),
+		ClientAs:      qualify(SDKImportPath, "ClientAs"),
+		Ingress:       qualify(IngressImportPath, "Client"),
+		Requester:     qualify(IngressImportPath, "Requester"),
+	}
+
+	switch service.Type {
+	case ServiceTypeService:
+		data.Kind = "Service"
+		data.Accessor = "Service"
+		data.Requests = qualify(IngressImportPath, "Service")
+	case ServiceTypeObject:
+		data.Kind = "Virtual Object"
+		data.Accessor = "Object"
+		data.Requests = qualify(IngressImportPath, "Object")
+	case ServiceTypeWorkflow:
+		data.Kind = "Workflow"
+		// workflows are addressed by key, just like objects
+		data.Accessor = "Object"
+		data.Requests = qualify(IngressImportPath, "Workflow")
+	}
+
+	if len(service.Options) > 0 {
+		data.Options = ", " + strings.Join(service.Options, ", ")
+	}
+
+	return data
+}
+
+func unexport(name string) string {
+	runes := []rune(name)
+	for i := range runes {
+		// lower the leading run of upper case letters, keeping the start of the next word, eg HTTPServer -> httpServer
+		if !unicode.IsUpper(runes[i]) || (i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// GoName converts a Restate service or handler name into an exported Go identifier
+func GoName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if b.Len() == 0 && unicode.IsDigit(r) {
+			b.WriteRune('X')
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var clientTemplate = template.Must(template.New("client").Parse(`
+// {{.GoName}}Client is a typed client for the {{.Name}} {{.Kind}}, for use within a handler
+type {{.GoName}}Client interface {
+{{- range .Handlers}}
+	{{.GoName}}() {{$.Typed}}[{{.Input}}, {{.Output}}]
+{{- end}}
+}
+
+type {{.Client}} struct {
+	ctx {{.Context}}
+{{- if .Keyed}}
+	key string
+{{- end}}
+}
+
+// New{{.GoName}}Client returns a typed client for the {{.Name}} {{.Kind}}
+func New{{.GoName}}Client(ctx {{.Context}}{{if .Keyed}}, key string{{end}}) {{.GoName}}Client {
+	return &{{.Client}}{ctx{{if .Keyed}}, key{{end}}}
+}
+{{range .Handlers}}
+func (c *{{$.Client}}) {{.GoName}}() {{$.Typed}}[{{.Input}}, {{.Output}}] {
+	return {{$.ClientAs}}[{{.Input}}, {{.Output}}](c.ctx.{{$.Accessor}}({{printf "%q" $.Name}}{{if $.Keyed}}, c.key{{end}}, {{printf "%q" .Name}}{{$.Options}}))
+}
+{{end}}
+// {{.GoName}}IngressClient is a typed client for the {{.Name}} {{.Kind}}, for use through the Restate ingress
+type {{.GoName}}IngressClient interface {
+{{- range .Handlers}}
+	{{.GoName}}() {{$.Requester}}[{{.Input}}, {{.Output}}]
+{{- end}}
+}
+
+type {{.IngressClient}} struct {
+	client *{{.Ingress}}
+{{- if .Keyed}}
+	key    string
+{{- end}}
+}
+
+// New{{.GoName}}IngressClient returns a typed client for the {{.Name}} {{.Kind}} which uses the provided ingress client
+func New{{.GoName}}IngressClient(client *{{.Ingress}}{{if .Keyed}}, key string{{end}}) {{.GoName}}IngressClient {
+	return &{{.IngressClient}}{client{{if .Keyed}}, key{{end}}}
+}
+{{range .Handlers}}
+func (c *{{$.IngressClient}}) {{.GoName}}() {{$.Requester}}[{{.Input}}, {{.Output}}] {
+	return {{$.Requests}}[{{.Input}}, {{.Output}}](c.client, {{printf "%q" $.Name}}{{if $.Keyed}}, c.key{{end}}, {{printf "%q" .Name}}{{$.Options}})
+}
+{{end}}`))
@@ -0,0 +1,49 @@
+package codegen
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoName(t *testing.T) {
+	require.Equal(t, "Checkout", GoName("Checkout"))
+	require.Equal(t, "UserSession", GoName("userSession"))
+	require.Equal(t, "MyService", GoName("my-service"))
+	require.Equal(t, "X1service", GoName("1service"))
+}
+
+func TestUnexport(t *testing.T) {
+	require.Equal(t, "checkout", unexport("Checkout"))
+	require.Equal(t, "httpServer", unexport("HTTPServer"))
+	require.Equal(t, "api", unexport("API"))
+}
+
+func TestWrite(t *testing.T) {
+	qualify := func(importPath, name string) string {
+		if importPath == SDKImportPath {
+			return "restate." + name
+		}
+		return "ingress." + name
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("package test\n")
+	require.NoError(t, Write(&buf, qualify, []Service{
+		{GoName: "Greeter", Name: "Greeter", Type: ServiceTypeService, Handlers: []Handler{
+			{GoName: "Greet", Name: "Greet", Input: "string", Output: "string"},
+		}},
+		{GoName: "Counter", Name: "Counter", Type: ServiceTypeObject, Handlers: []Handler{
+			{GoName: "Add", Name: "Add", Input: "int", Output: "restate.Void"},
+		}, Options: []string{"restate.WithBinary"}},
+	}))
+
+	_, err := parser.ParseFile(token.NewFileSet(), "test.go", buf.Bytes(), 0)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), `func NewGreeterClient(ctx restate.Context) GreeterClient`)
+	require.Contains(t, buf.String(), `restate.ClientAs[int, restate.Void](c.ctx.Object("Counter", c.key, "Add", restate.WithBinary))`)
+	require.Contains(t, buf.String(), `ingress.Object[int, restate.Void](c.client, "Counter", c.key, "Add", restate.WithBinary)`)
+}
@@ -37,6 +37,8 @@ type Service struct {
 	Name     string      `json:"name"`
 	Ty       ServiceType `json:"ty"`
 	Handlers []Handler   `json:"handlers"`
+	// If unspecified, state is sent eagerly with each invocation. Only defined from discovery protocol V2.
+	EnableLazyState *bool `json:"enableLazyState,omitempty"`
 }
 
 type Endpoint struct {
@@ -14,12 +14,23 @@ const (
 )
 
 var (
-	ErrKeyNotFound = NewTerminalError(fmt.Errorf("key not found"), 404)
+	ErrKeyNotFound      = NewTerminalError(fmt.Errorf("key not found"), 404)
+	ErrTimeout          = NewTerminalError(fmt.Errorf("timed out"), 408)
+	ErrCancelled        = NewTerminalError(fmt.Errorf(cancelledMessage), 409)
+	ErrDeadlineExceeded = NewTerminalError(fmt.Errorf("deadline exceeded"), 408)
+	ErrTimerCancelled   = NewTerminalError(fmt.Errorf("timer cancelled"), 409)
+	ErrNoFutures        = NewTerminalError(fmt.Errorf("no futures to wait for"), 400)
 )
 
+// cancelledMessage is the message of the failure that Restate completes the operations of a cancelled invocation
+// with, along with code 409
+const cancelledMessage = "canceled"
+
 type CodeError struct {
 	Code  Code
 	Inner error
+	// cancelled is set by ErrorFromFailure for the failure of a cancelled invocation
+	cancelled bool
 }
 
 func (e *CodeError) Error() string {
@@ -30,6 +41,12 @@ func (e *CodeError) Unwrap() error {
 	return e.Inner
 }
 
+// Is matches ErrCancelled for the failures of a cancelled invocation received from Restate, which are distinct
+// values with the same code and message
+func (e *CodeError) Is(target error) bool {
+	return target == ErrCancelled && e.cancelled
+}
+
 type TerminalError struct {
 	Inner error
 }
@@ -43,7 +60,11 @@ func (e *TerminalError) Unwrap() error {
 }
 
 func ErrorFromFailure(failure *protocol.Failure) error {
-	return &CodeError{Inner: &TerminalError{Inner: fmt.Errorf(failure.Message)}, Code: Code(failure.Code)}
+	return &CodeError{
+		Inner:     &TerminalError{Inner: fmt.Errorf(failure.Message)},
+		Code:      Code(failure.Code),
+		cancelled: failure.Code == 409 && failure.Message == cancelledMessage,
+	}
 }
 
 func NewTerminalError(err error, code ...Code) error {
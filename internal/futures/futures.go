@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/binary"
 	"fmt"
+	"sync"
 
 	"github.com/restatedev/sdk-go/generated/proto/protocol"
 	"github.com/restatedev/sdk-go/internal/errors"
@@ -15,6 +16,9 @@ var (
 	_ Selectable = (*After)(nil)
 	_ Selectable = (*Awakeable)(nil)
 	_ Selectable = (*ResponseFuture)(nil)
+	_ Selectable = (*Promise)(nil)
+	_ Selectable = (*AttachFuture)(nil)
+	_ Selectable = (*InvocationOutputFuture)(nil)
 )
 
 type After struct {
@@ -56,7 +60,7 @@ func NewAwakeable(suspensionCtx context.Context, invocationID []byte, entry *wir
 	return &Awakeable{suspensionCtx, invocationID, entry, entryIndex}
 }
 
-func (c *Awakeable) Id() string { return awakeableID(c.invocationID, c.entryIndex) }
+func (c *Awakeable) Id() string { return AwakeableID(c.invocationID, c.entryIndex) }
 func (c *Awakeable) Result() ([]byte, error) {
 	c.entry.Await(c.suspensionCtx, c.entryIndex)
 
@@ -73,11 +77,12 @@ func (c *Awakeable) getEntry() (wire.CompleteableMessage, uint32) {
 	return c.entry, c.entryIndex
 }
 
-func awakeableID(invocationID []byte, entryIndex uint32) string {
+// AwakeableID returns the ID of the awakeable created at entryIndex of the invocation with invocationID
+func AwakeableID(invocationID []byte, entryIndex uint32) string {
 	bytes := make([]byte, 0, len(invocationID)+4)
 	bytes = append(bytes, invocationID...)
 	bytes = binary.BigEndian.AppendUint32(bytes, entryIndex)
-	return "prom_1" + base64.RawURLEncoding.EncodeToString(bytes)
+	return AWAKEABLE_IDENTIFIER_PREFIX + base64.RawURLEncoding.EncodeToString(bytes)
 }
 
 type ResponseFuture struct {
@@ -107,3 +112,91 @@ func (r *ResponseFuture) Response() ([]byte, error) {
 func (r *ResponseFuture) getEntry() (wire.CompleteableMessage, uint32) {
 	return r.entry, r.entryIndex
 }
+
+type Promise struct {
+	suspensionCtx context.Context
+	// the get promise entry is only written to the journal when it is first needed
+	entry                func() (*wire.GetPromiseEntryMessage, uint32)
+	newProtocolViolation func(wire.Message, error) any
+}
+
+func NewPromise(suspensionCtx context.Context, newEntry func() (*wire.GetPromiseEntryMessage, uint32), newProtocolViolation func(wire.Message, error) any) *Promise {
+	return &Promise{suspensionCtx, sync.OnceValues(newEntry), newProtocolViolation}
+}
+
+func (p *Promise) Result() ([]byte, error) {
+	entry, entryIndex := p.entry()
+	entry.Await(p.suspensionCtx, entryIndex)
+
+	switch result := entry.Result.(type) {
+	case *protocol.GetPromiseEntryMessage_Value:
+		return result.Value, nil
+	case *protocol.GetPromiseEntryMessage_Failure:
+		return nil, errors.ErrorFromFailure(result.Failure)
+	default:
+		panic(p.newProtocolViolation(entry, fmt.Errorf("get promise entry had invalid result: %v", entry.Result)))
+	}
+}
+
+func (p *Promise) getEntry() (wire.CompleteableMessage, uint32) {
+	return p.entry()
+}
+
+type AttachFuture struct {
+	suspensionCtx        context.Context
+	entry                *wire.AttachInvocationEntryMessage
+	entryIndex           uint32
+	newProtocolViolation func(error) any
+}
+
+func NewAttachFuture(suspensionCtx context.Context, entry *wire.AttachInvocationEntryMessage, entryIndex uint32, newProtocolViolation func(error) any) *AttachFuture {
+	return &AttachFuture{suspensionCtx, entry, entryIndex, newProtocolViolation}
+}
+
+func (a *AttachFuture) Response() ([]byte, error) {
+	a.entry.Await(a.suspensionCtx, a.entryIndex)
+
+	switch result := a.entry.Result.(type) {
+	case *protocol.AttachInvocationEntryMessage_Value:
+		return result.Value, nil
+	case *protocol.AttachInvocationEntryMessage_Failure:
+		return nil, errors.ErrorFromFailure(result.Failure)
+	default:
+		panic(a.newProtocolViolation(fmt.Errorf("attach invocation entry had invalid result: %v", a.entry.Result)))
+	}
+}
+
+func (a *AttachFuture) getEntry() (wire.CompleteableMessage, uint32) {
+	return a.entry, a.entryIndex
+}
+
+type InvocationOutputFuture struct {
+	suspensionCtx        context.Context
+	entry                *wire.GetInvocationOutputEntryMessage
+	entryIndex           uint32
+	newProtocolViolation func(error) any
+}
+
+func NewInvocationOutputFuture(suspensionCtx context.Context, entry *wire.GetInvocationOutputEntryMessage, entryIndex uint32, newProtocolViolation func(error) any) *InvocationOutputFuture {
+	return &InvocationOutputFuture{suspensionCtx, entry, entryIndex, newProtocolViolation}
+}
+
+// Output returns the output of the invocation, or ok=false if it has not completed yet
+func (o *InvocationOutputFuture) Output() (output []byte, ok bool, err error) {
+	o.entry.Await(o.suspensionCtx, o.entryIndex)
+
+	switch result := o.entry.Result.(type) {
+	case *protocol.GetInvocationOutputEntryMessage_Empty:
+		return nil, false, nil
+	case *protocol.GetInvocationOutputEntryMessage_Value:
+		return result.Value, true, nil
+	case *protocol.GetInvocationOutputEntryMessage_Failure:
+		return nil, true, errors.ErrorFromFailure(result.Failure)
+	default:
+		panic(o.newProtocolViolation(fmt.Errorf("get invocation output entry had invalid result: %v", o.entry.Result)))
+	}
+}
+
+func (o *InvocationOutputFuture) getEntry() (wire.CompleteableMessage, uint32) {
+	return o.entry, o.entryIndex
+}
@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"slices"
 
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/internal/errors"
 	"github.com/restatedev/sdk-go/internal/wire"
 )
 
@@ -29,6 +31,16 @@ func (s *Selector) Select() (uint32, bool) {
 	}
 
 	indexes := s.Indexes()
+	// if operations have already completed, take the one with the earliest entry rather than racing them against
+	// each other and the suspension, so that the choice doesn't depend on the order in which completions arrived
+	for _, entryIndex := range indexes {
+		select {
+		case <-s.indexedChans[entryIndex]:
+			return entryIndex, true
+		default:
+		}
+	}
+
 	cases := make([]reflect.SelectCase, len(indexes)+1)
 	for i, entryIndex := range indexes {
 		cases[i] = reflect.SelectCase{
@@ -91,3 +103,47 @@ func Select(suspensionCtx context.Context, futs ...Selectable) *Selector {
 	}
 	return s
 }
+
+// EntryIndex returns the index of the journal entry of s
+func EntryIndex(s Selectable) uint32 {
+	_, entryIndex := s.getEntry()
+	return entryIndex
+}
+
+// Completed returns whether the entry of s has been completed
+func Completed(s Selectable) bool {
+	entry, _ := s.getEntry()
+	return entry.Completed()
+}
+
+// Failure returns the error that the completed entry of s failed with, or nil if it succeeded
+func Failure(s Selectable) error {
+	entry, _ := s.getEntry()
+	switch entry := entry.(type) {
+	case *wire.SleepEntryMessage:
+		if result, ok := entry.Result.(*protocol.SleepEntryMessage_Failure); ok {
+			return errors.ErrorFromFailure(result.Failure)
+		}
+	case *wire.AwakeableEntryMessage:
+		if result, ok := entry.Result.(*protocol.AwakeableEntryMessage_Failure); ok {
+			return errors.ErrorFromFailure(result.Failure)
+		}
+	case *wire.CallEntryMessage:
+		if result, ok := entry.Result.(*protocol.CallEntryMessage_Failure); ok {
+			return errors.ErrorFromFailure(result.Failure)
+		}
+	case *wire.GetPromiseEntryMessage:
+		if result, ok := entry.Result.(*protocol.GetPromiseEntryMessage_Failure); ok {
+			return errors.ErrorFromFailure(result.Failure)
+		}
+	case *wire.AttachInvocationEntryMessage:
+		if result, ok := entry.Result.(*protocol.AttachInvocationEntryMessage_Failure); ok {
+			return errors.ErrorFromFailure(result.Failure)
+		}
+	case *wire.GetInvocationOutputEntryMessage:
+		if result, ok := entry.Result.(*protocol.GetInvocationOutputEntryMessage_Failure); ok {
+			return errors.ErrorFromFailure(result.Failure)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package futures
+
+import (
+	"context"
+	"testing"
+
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/internal/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func completedSleep(t *testing.T, suspensionCtx context.Context, entryIndex uint32) *After {
+	entry := &wire.SleepEntryMessage{}
+	require.NoError(t, entry.Complete(&protocol.CompletionMessage{
+		EntryIndex: entryIndex,
+		Result:     &protocol.CompletionMessage_Empty{Empty: &protocol.Empty{}},
+	}))
+	return NewAfter(suspensionCtx, entry, entryIndex)
+}
+
+func TestSelectPrefersEarliestCompleted(t *testing.T) {
+	// the input has been closed, so the selector suspends once no completed operations are left
+	suspensionCtx, suspend := context.WithCancel(context.Background())
+	suspend()
+
+	selector := Select(suspensionCtx,
+		completedSleep(t, suspensionCtx, 3),
+		NewAfter(suspensionCtx, &wire.SleepEntryMessage{}, 1),
+		completedSleep(t, suspensionCtx, 2),
+	)
+
+	for _, expected := range []uint32{2, 3} {
+		for i := 0; i < 10; i++ {
+			index, ok := selector.Select()
+			require.True(t, ok)
+			require.Equal(t, expected, index)
+		}
+		require.NotNil(t, selector.Take(expected))
+	}
+
+	var suspension *wire.SuspensionPanic
+	func() {
+		defer func() {
+			suspension, _ = recover().(*wire.SuspensionPanic)
+		}()
+		selector.Select()
+	}()
+	require.NotNil(t, suspension)
+	require.Equal(t, []uint32{1}, suspension.EntryIndexes)
+}
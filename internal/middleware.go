@@ -0,0 +1,14 @@
+package internal
+
+import "fmt"
+
+// ContextAs converts the context that middleware passed on to the innermost handler back into the context type C
+// of the handler, which is a programming error in the middleware if it is not
+func ContextAs[C any](ctx any) C {
+	c, ok := ctx.(C)
+	if !ok {
+		var expected C
+		panic(fmt.Sprintf("middleware passed a Context of type %T to a handler which requires %T", ctx, &expected))
+	}
+	return c
+}
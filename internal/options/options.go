@@ -1,9 +1,15 @@
 package options
 
-import "github.com/restatedev/sdk-go/encoding"
+import (
+	"net/http"
+	"time"
+
+	"github.com/restatedev/sdk-go/encoding"
+)
 
 type AwakeableOptions struct {
 	Codec encoding.Codec
+	Name  string
 }
 
 type AwakeableOption interface {
@@ -34,25 +40,83 @@ type SetOption interface {
 	BeforeSet(*SetOptions)
 }
 
+type StateKeyOptions struct {
+	Codec encoding.Codec
+}
+
+type StateKeyOption interface {
+	BeforeStateKey(*StateKeyOptions)
+}
+
+type PromiseOptions struct {
+	Codec encoding.Codec
+}
+
+type PromiseOption interface {
+	BeforePromise(*PromiseOptions)
+}
+
 type CallOptions struct {
-	Codec   encoding.Codec
-	Headers map[string]string
+	Codec          encoding.Codec
+	Headers        map[string]string
+	Name           string
+	IdempotencyKey string
 }
 
 type CallOption interface {
 	BeforeCall(*CallOptions)
 }
 
-type RunOptions struct {
+type AttachOptions struct {
 	Codec encoding.Codec
+	Name  string
+}
+
+type AttachOption interface {
+	BeforeAttach(*AttachOptions)
+}
+
+type SleepOptions struct {
+	Name string
+}
+
+type SleepOption interface {
+	BeforeSleep(*SleepOptions)
+}
+
+type RunOptions struct {
+	Codec       encoding.Codec
+	Name        string
+	RetryPolicy RunRetryPolicy
+}
+
+// RunRetryPolicy controls in-process retries of a Run. The zero value disables them, leaving retries to Restate.
+type RunRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first; zero means unlimited
+	MaxAttempts uint
+	// InitialInterval is the delay before the first retry
+	InitialInterval time.Duration
+	// Factor is the multiplier applied to the delay after each retry
+	Factor float64
+	// MaxDuration is the maximum time spent retrying since the first attempt; zero means unlimited
+	MaxDuration time.Duration
+}
+
+// Enabled returns true if any retry option was provided
+func (p RunRetryPolicy) Enabled() bool {
+	return p != RunRetryPolicy{}
 }
 
 type RunOption interface {
 	BeforeRun(*RunOptions)
 }
 
+// Middlewares holds values of type restate.Middleware, which cannot be referenced from this package
+type Middlewares []any
+
 type ServiceHandlerOptions struct {
-	Codec encoding.PayloadCodec
+	Codec      encoding.PayloadCodec
+	Middleware Middlewares
 }
 
 type ServiceHandlerOption interface {
@@ -60,15 +124,26 @@ type ServiceHandlerOption interface {
 }
 
 type ObjectHandlerOptions struct {
-	Codec encoding.PayloadCodec
+	Codec      encoding.PayloadCodec
+	Middleware Middlewares
 }
 
 type ObjectHandlerOption interface {
 	BeforeObjectHandler(*ObjectHandlerOptions)
 }
 
+type WorkflowHandlerOptions struct {
+	Codec      encoding.PayloadCodec
+	Middleware Middlewares
+}
+
+type WorkflowHandlerOption interface {
+	BeforeWorkflowHandler(*WorkflowHandlerOptions)
+}
+
 type ServiceOptions struct {
 	DefaultCodec encoding.PayloadCodec
+	Middleware   Middlewares
 }
 
 type ServiceOption interface {
@@ -77,8 +152,50 @@ type ServiceOption interface {
 
 type ObjectOptions struct {
 	DefaultCodec encoding.PayloadCodec
+	Middleware   Middlewares
+	// LazyState is nil if the server default should be used
+	LazyState *bool
 }
 
 type ObjectOption interface {
 	BeforeObject(*ObjectOptions)
 }
+
+type WorkflowOptions struct {
+	DefaultCodec encoding.PayloadCodec
+	Middleware   Middlewares
+	// LazyState is nil if the server default should be used
+	LazyState *bool
+}
+
+type WorkflowOption interface {
+	BeforeWorkflow(*WorkflowOptions)
+}
+
+type IngressClientOptions struct {
+	HTTPClient *http.Client
+	Headers    map[string]string
+}
+
+type IngressClientOption interface {
+	BeforeIngressClient(*IngressClientOptions)
+}
+
+type IngressRequestOptions struct {
+	Codec          encoding.Codec
+	Headers        map[string]string
+	IdempotencyKey string
+}
+
+type IngressRequestOption interface {
+	BeforeIngressRequest(*IngressRequestOptions)
+}
+
+type IngressSendOptions struct {
+	IngressRequestOptions
+	Delay time.Duration
+}
+
+type IngressSendOption interface {
+	BeforeIngressSend(*IngressSendOptions)
+}
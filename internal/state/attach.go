@@ -0,0 +1,73 @@
+package state
+
+import (
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/internal/futures"
+	"github.com/restatedev/sdk-go/internal/wire"
+)
+
+func (m *Machine) attachInvocation(invocationId, name string) (*futures.AttachFuture, error) {
+	if err := m.requireProtocolVersion(protocol.ServiceProtocolVersion_V3, "attaching to an invocation"); err != nil {
+		return nil, err
+	}
+
+	entry, entryIndex := replayOrNew(
+		m,
+		func(entry *wire.AttachInvocationEntryMessage) *wire.AttachInvocationEntryMessage {
+			if entry.GetInvocationId() != invocationId || entry.Name != name {
+				panic(m.newEntryMismatch(&wire.AttachInvocationEntryMessage{
+					AttachInvocationEntryMessage: protocol.AttachInvocationEntryMessage{
+						Target: &protocol.AttachInvocationEntryMessage_InvocationId{InvocationId: invocationId},
+						Name:   name,
+					},
+				}, entry))
+			}
+			return entry
+		},
+		func() *wire.AttachInvocationEntryMessage {
+			msg := &wire.AttachInvocationEntryMessage{
+				AttachInvocationEntryMessage: protocol.AttachInvocationEntryMessage{
+					Target: &protocol.AttachInvocationEntryMessage_InvocationId{InvocationId: invocationId},
+					Name:   name,
+				},
+			}
+			m.Write(msg)
+			return msg
+		},
+	)
+
+	return futures.NewAttachFuture(m.suspensionCtx, entry, entryIndex, func(err error) any { return m.newProtocolViolation(entry, err) }), nil
+}
+
+func (m *Machine) getInvocationOutput(invocationId, name string) (*futures.InvocationOutputFuture, error) {
+	if err := m.requireProtocolVersion(protocol.ServiceProtocolVersion_V3, "getting the output of an invocation"); err != nil {
+		return nil, err
+	}
+
+	entry, entryIndex := replayOrNew(
+		m,
+		func(entry *wire.GetInvocationOutputEntryMessage) *wire.GetInvocationOutputEntryMessage {
+			if entry.GetInvocationId() != invocationId || entry.Name != name {
+				panic(m.newEntryMismatch(&wire.GetInvocationOutputEntryMessage{
+					GetInvocationOutputEntryMessage: protocol.GetInvocationOutputEntryMessage{
+						Target: &protocol.GetInvocationOutputEntryMessage_InvocationId{InvocationId: invocationId},
+						Name:   name,
+					},
+				}, entry))
+			}
+			return entry
+		},
+		func() *wire.GetInvocationOutputEntryMessage {
+			msg := &wire.GetInvocationOutputEntryMessage{
+				GetInvocationOutputEntryMessage: protocol.GetInvocationOutputEntryMessage{
+					Target: &protocol.GetInvocationOutputEntryMessage_InvocationId{InvocationId: invocationId},
+					Name:   name,
+				},
+			}
+			m.Write(msg)
+			return msg
+		},
+	)
+
+	return futures.NewInvocationOutputFuture(m.suspensionCtx, entry, entryIndex, func(err error) any { return m.newProtocolViolation(entry, err) }), nil
+}
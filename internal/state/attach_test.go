@@ -0,0 +1,90 @@
+package state_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachInvocation(t *testing.T) {
+	handler := restate.NewServiceHandler(func(ctx restate.Context, _ restate.Void) ([]string, error) {
+		greeting, err := restate.CallAs[string](ctx.Service("Greeter", "greet", restate.WithIdempotencyKey("greet-bob"))).Request("bob")
+		if err != nil {
+			return nil, err
+		}
+		if err := ctx.Service("Greeter", "greet", restate.WithIdempotencyKey("greet-alice")).Send("alice", 0); err != nil {
+			return nil, err
+		}
+
+		attached, err := restate.AttachInvocationAs[string](ctx, "inv_done")
+		if err != nil {
+			return nil, err
+		}
+		done, err := attached.Response()
+		if err != nil {
+			return nil, err
+		}
+
+		running, err := restate.GetInvocationOutputAs[string](ctx, "inv_running")
+		if err != nil {
+			return nil, err
+		}
+		if _, ok, err := running.Output(); err != nil || ok {
+			return nil, fmt.Errorf("expected no output yet, got ok=%v err=%v", ok, err)
+		}
+
+		failed, err := ctx.GetInvocationOutput("inv_failed")
+		if err != nil {
+			return nil, err
+		}
+		var output string
+		if ok, err := failed.Output(&output); !ok || restate.ErrorCode(err) != 500 {
+			return nil, fmt.Errorf("expected a failure, got ok=%v err=%v", ok, err)
+		}
+
+		return []string{greeting, done}, nil
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	runtime := restatetest.Runtime{
+		Call: func(target restatetest.Target, input []byte) *restatetest.Completion {
+			return restatetest.Value(restatetest.JSON(t, "hello"))
+		},
+		Invocations: map[string]*restatetest.Completion{
+			"inv_done":   restatetest.Value(restatetest.JSON(t, "done")),
+			"inv_failed": restatetest.Failure(restate.TerminalError(fmt.Errorf("boom"), 500)),
+		},
+	}
+	result := runtime.Complete(t, handler, nil)
+	require.NoError(t, result.TerminalError)
+	require.Equal(t, restatetest.JSON(t, []string{"hello", "done"}), result.Output)
+
+	call, ok := result.Journal[0].(*protocol.CallEntryMessage)
+	require.True(t, ok)
+	require.Equal(t, "greet-bob", call.GetIdempotencyKey())
+	send, ok := result.Journal[1].(*protocol.OneWayCallEntryMessage)
+	require.True(t, ok)
+	require.Equal(t, "greet-alice", send.GetIdempotencyKey())
+	attach, ok := result.Journal[2].(*protocol.AttachInvocationEntryMessage)
+	require.True(t, ok)
+	require.Equal(t, "inv_done", attach.GetInvocationId())
+	require.IsType(t, &protocol.GetInvocationOutputEntryMessage{}, result.Journal[3])
+
+	t.Run("requires protocol V3", func(t *testing.T) {
+		handler := restate.NewServiceHandler(func(ctx restate.Context, _ restate.Void) (restate.Void, error) {
+			_, err := ctx.AttachInvocation("inv_done")
+			return restate.Void{}, err
+		})
+		restate.NewService("Test").Handler("handle", handler)
+
+		runtime := restatetest.Runtime{ProtocolVersion: protocol.ServiceProtocolVersion_V2}
+		result, err := runtime.Invoke(context.Background(), handler, nil)
+		require.NoError(t, err)
+		require.ErrorContains(t, result.Error, "requires service protocol V3")
+	})
+}
@@ -9,20 +9,26 @@ import (
 	"github.com/restatedev/sdk-go/internal/wire"
 )
 
-func (c *Machine) awakeable() *futures.Awakeable {
+func (c *Machine) awakeable(name string) *futures.Awakeable {
 	entry, entryIndex := replayOrNew(
 		c,
 		func(entry *wire.AwakeableEntryMessage) *wire.AwakeableEntryMessage {
 			return entry
 		},
-		c._awakeable,
+		func() *wire.AwakeableEntryMessage {
+			return c._awakeable(name)
+		},
 	)
 
 	return futures.NewAwakeable(c.suspensionCtx, c.request.ID, entry, entryIndex)
 }
 
-func (c *Machine) _awakeable() *wire.AwakeableEntryMessage {
-	msg := &wire.AwakeableEntryMessage{}
+func (c *Machine) _awakeable(name string) *wire.AwakeableEntryMessage {
+	msg := &wire.AwakeableEntryMessage{
+		AwakeableEntryMessage: protocol.AwakeableEntryMessage{
+			Name: name,
+		},
+	}
 	c.Write(msg)
 	return msg
 }
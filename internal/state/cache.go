@@ -0,0 +1,77 @@
+package state
+
+import (
+	"sort"
+)
+
+// stateCache holds the state of the Virtual Object or Workflow as known to the machine. It is filled from the
+// state eagerly sent in the StartMessage and from the results of lookups, and kept up to date with the mutations
+// made by the handler, so that only keys which have never been seen in this attempt need to be fetched from the
+// runtime.
+type stateCache struct {
+	values map[string][]byte
+	// keys known to have no value; only needed while the state is partial
+	absent map[string]struct{}
+	// set if the runtime may hold keys which are not in values, ie lazy state was requested or the eager state
+	// was too large to send in full
+	partial bool
+}
+
+func newStateCache() stateCache {
+	return stateCache{
+		values: make(map[string][]byte),
+		absent: make(map[string]struct{}),
+	}
+}
+
+// get returns the value of key, and whether it is known without asking the runtime
+func (c *stateCache) get(key string) ([]byte, bool) {
+	if value, ok := c.values[key]; ok {
+		return value, true
+	}
+	if _, ok := c.absent[key]; ok {
+		return nil, true
+	}
+	return nil, !c.partial
+}
+
+// fill records a value received from the runtime, where nil means that the key has no value
+func (c *stateCache) fill(key string, value []byte) {
+	if value == nil {
+		delete(c.values, key)
+		c.absent[key] = struct{}{}
+		return
+	}
+	c.values[key] = value
+	delete(c.absent, key)
+}
+
+func (c *stateCache) set(key string, value []byte) {
+	c.values[key] = value
+	delete(c.absent, key)
+}
+
+func (c *stateCache) clear(key string) {
+	delete(c.values, key)
+	c.absent[key] = struct{}{}
+}
+
+// clearAll empties the state, which is then known in full
+func (c *stateCache) clearAll() {
+	c.values = make(map[string][]byte)
+	c.absent = make(map[string]struct{})
+	c.partial = false
+}
+
+// keys returns the sorted keys with a value, and whether they are known to be all of the keys
+func (c *stateCache) keys() ([]string, bool) {
+	if c.partial {
+		return nil, false
+	}
+	keys := make([]string, 0, len(c.values))
+	for k := range c.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, true
+}
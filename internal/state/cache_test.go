@@ -0,0 +1,67 @@
+package state_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazyState(t *testing.T) {
+	handler := restate.NewObjectHandler(func(ctx restate.ObjectContext, _ restate.Void) ([]string, error) {
+		ctx.Prefetch("a", "b", "missing")
+		a, err := restate.GetAs[string](ctx, "a")
+		if err != nil {
+			return nil, err
+		}
+		b, err := restate.GetAs[string](ctx, "b")
+		if err != nil {
+			return nil, err
+		}
+		ctx.Clear("a")
+		if _, err := restate.GetAs[string](ctx, "a"); !errors.Is(err, restate.ErrKeyNotFound) {
+			return nil, fmt.Errorf("expected cleared key to be missing, got %v", err)
+		}
+		if _, err := restate.GetAs[string](ctx, "missing"); !errors.Is(err, restate.ErrKeyNotFound) {
+			return nil, fmt.Errorf("expected missing key to be missing, got %v", err)
+		}
+		if err := ctx.Set("c", a+b); err != nil {
+			return nil, err
+		}
+		return ctx.Keys(), nil
+	})
+	restate.NewObject("Lazy", restate.WithLazyState(true)).Handler("handle", handler)
+
+	runtime := restatetest.Runtime{
+		Key:       "key",
+		LazyState: true,
+		State: map[string][]byte{
+			"a": restatetest.JSON(t, "a"),
+			"b": restatetest.JSON(t, "b"),
+		},
+	}
+
+	result := runtime.Complete(t, handler, nil)
+	require.NoError(t, result.TerminalError)
+	require.Equal(t, restatetest.JSON(t, []string{"b", "c"}), result.Output)
+	require.Equal(t, map[string][]byte{"b": restatetest.JSON(t, "b"), "c": restatetest.JSON(t, "ab")}, result.State)
+
+	// prefetched keys are journaled once; the later lookup of the cleared key is answered from the cache
+	kinds := make([]string, 0, len(result.Journal))
+	for _, entry := range result.Journal {
+		kinds = append(kinds, fmt.Sprintf("%T", entry))
+	}
+	require.Equal(t, []string{
+		"*protocol.GetStateEntryMessage",
+		"*protocol.GetStateEntryMessage",
+		"*protocol.GetStateEntryMessage",
+		"*protocol.ClearStateEntryMessage",
+		"*protocol.GetStateEntryMessage",
+		"*protocol.SetStateEntryMessage",
+		"*protocol.GetStateKeysEntryMessage",
+		"*protocol.OutputEntryMessage",
+	}, kinds)
+}
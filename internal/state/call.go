@@ -30,21 +30,49 @@ func (c *serviceCall) RequestFuture(input any) (restate.ResponseFuture, error) {
 	if err != nil {
 		return nil, errors.NewTerminalError(fmt.Errorf("failed to marshal RequestFuture input: %w", err))
 	}
+	if err := c.checkIdempotencyKey(); err != nil {
+		return nil, err
+	}
 
-	entry, entryIndex := c.machine.doCall(c.service, c.key, c.method, c.options.Headers, bytes)
+	entry, entryIndex := c.machine.doCall(c.service, c.key, c.method, c.options.Name, c.options.IdempotencyKey, c.options.Headers, bytes)
 
 	return decodingResponseFuture{
 		futures.NewResponseFuture(c.machine.suspensionCtx, entry, entryIndex, func(err error) any { return c.machine.newProtocolViolation(entry, err) }),
+		invocationHandle{c.machine, entryIndex},
 		c.options,
 	}, nil
 }
 
+// invocationHandle refers to the invocation started by the call or one way call entry at entryIndex
+type invocationHandle struct {
+	machine    *Machine
+	entryIndex uint32
+}
+
+func (h invocationHandle) InvocationId() (string, error) {
+	return h.machine.getCallInvocationId(h.entryIndex)
+}
+
+func (h invocationHandle) Cancel() error {
+	return h.machine.cancelCall(h.entryIndex)
+}
+
 type decodingResponseFuture struct {
 	*futures.ResponseFuture
+	invocationHandle
 	options options.CallOptions
 }
 
 func (d decodingResponseFuture) Response(output any) (err error) {
+	if err := d.machine.awaitDeadline(d.ResponseFuture); err != nil {
+		return err
+	}
+	return d.CompletedResponse(output)
+}
+
+// CompletedResponse is like Response without racing the deadline of the handler, for a call that is known to have
+// completed, eg because it was returned by a selector
+func (d decodingResponseFuture) CompletedResponse(output any) (err error) {
 	bytes, err := d.ResponseFuture.Response()
 	if err != nil {
 		return err
@@ -68,15 +96,55 @@ func (c *serviceCall) Request(input any, output any) error {
 
 // Send runs a call in the background after delay duration
 func (c *serviceCall) Send(input any, delay time.Duration) error {
+	_, err := c.send(input, afterDelay(delay))
+	return err
+}
+
+// SendAt runs a call in the background at the time at
+func (c *serviceCall) SendAt(input any, at time.Time) error {
+	_, err := c.send(input, func() time.Time { return at })
+	return err
+}
+
+// SendWithHandle runs a call in the background after delay duration, returning a handle on the invocation
+func (c *serviceCall) SendWithHandle(input any, delay time.Duration) (restate.InvocationHandle, error) {
+	entryIndex, err := c.send(input, afterDelay(delay))
+	if err != nil {
+		return nil, err
+	}
+	return invocationHandle{c.machine, entryIndex}, nil
+}
+
+func (c *serviceCall) send(input any, invokeTime func() time.Time) (uint32, error) {
 	bytes, err := encoding.Marshal(c.options.Codec, input)
 	if err != nil {
-		return errors.NewTerminalError(fmt.Errorf("failed to marshal Send input: %w", err))
+		return 0, errors.NewTerminalError(fmt.Errorf("failed to marshal Send input: %w", err))
+	}
+	if err := c.checkIdempotencyKey(); err != nil {
+		return 0, err
+	}
+	return c.machine.sendCall(c.service, c.key, c.method, c.options.Name, c.options.IdempotencyKey, c.options.Headers, bytes, invokeTime), nil
+}
+
+// afterDelay returns the invoke time of a call made after delay, where the zero time means immediately. The
+// current time is only read when the call is first journaled.
+func afterDelay(delay time.Duration) func() time.Time {
+	return func() time.Time {
+		if delay == 0 {
+			return time.Time{}
+		}
+		return time.Now().Add(delay)
 	}
-	c.machine.sendCall(c.service, c.key, c.method, c.options.Headers, bytes, delay)
-	return nil
 }
 
-func (m *Machine) doCall(service, key, method string, headersMap map[string]string, params []byte) (*wire.CallEntryMessage, uint32) {
+func (c *serviceCall) checkIdempotencyKey() error {
+	if c.options.IdempotencyKey == "" {
+		return nil
+	}
+	return c.machine.requireProtocolVersion(protocol.ServiceProtocolVersion_V3, "calling with an idempotency key")
+}
+
+func (m *Machine) doCall(service, key, method, name, idempotencyKey string, headersMap map[string]string, params []byte) (*wire.CallEntryMessage, uint32) {
 	headers := headersToProto(headersMap)
 
 	entry, entryIndex := replayOrNew(
@@ -85,34 +153,39 @@ func (m *Machine) doCall(service, key, method string, headersMap map[string]stri
 			if entry.ServiceName != service ||
 				entry.Key != key ||
 				entry.HandlerName != method ||
-				!headersEqual(entry.Headers, headers) ||
+				entry.GetIdempotencyKey() != idempotencyKey ||
+				!headersEqual(withoutTraceContext(entry.Headers, headers), headers) ||
 				!bytes.Equal(entry.Parameter, params) {
 				panic(m.newEntryMismatch(&wire.CallEntryMessage{
 					CallEntryMessage: protocol.CallEntryMessage{
-						ServiceName: service,
-						HandlerName: method,
-						Headers:     headers,
-						Parameter:   params,
-						Key:         key,
+						ServiceName:    service,
+						HandlerName:    method,
+						Headers:        headers,
+						Parameter:      params,
+						Key:            key,
+						IdempotencyKey: optionalString(idempotencyKey),
+						Name:           name,
 					},
 				}, entry))
 			}
 
 			return entry
 		}, func() *wire.CallEntryMessage {
-			return m._doCall(service, key, method, headers, params)
+			return m._doCall(service, key, method, name, idempotencyKey, headers, params)
 		})
 	return entry, entryIndex
 }
 
-func (m *Machine) _doCall(service, key, method string, headers []*protocol.Header, params []byte) *wire.CallEntryMessage {
+func (m *Machine) _doCall(service, key, method, name, idempotencyKey string, headers []*protocol.Header, params []byte) *wire.CallEntryMessage {
 	msg := &wire.CallEntryMessage{
 		CallEntryMessage: protocol.CallEntryMessage{
-			ServiceName: service,
-			HandlerName: method,
-			Parameter:   params,
-			Headers:     headers,
-			Key:         key,
+			ServiceName:    service,
+			HandlerName:    method,
+			Parameter:      params,
+			Headers:        m.injectTraceContext(headers),
+			Key:            key,
+			IdempotencyKey: optionalString(idempotencyKey),
+			Name:           name,
 		},
 	}
 	m.Write(msg)
@@ -120,6 +193,14 @@ func (m *Machine) _doCall(service, key, method string, headers []*protocol.Heade
 	return msg
 }
 
+// optionalString returns nil for the empty string, which is not a valid value of optional string fields
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 func headersEqual(left, right []*protocol.Header) bool {
 	if len(left) != len(right) {
 		return false
@@ -149,24 +230,27 @@ func headersToProto(headers map[string]string) []*protocol.Header {
 	return h
 }
 
-func (m *Machine) sendCall(service, key, method string, headersMap map[string]string, body []byte, delay time.Duration) {
+func (m *Machine) sendCall(service, key, method, name, idempotencyKey string, headersMap map[string]string, body []byte, invokeTime func() time.Time) uint32 {
 	headers := headersToProto(headersMap)
 
-	_, _ = replayOrNew(
+	_, entryIndex := replayOrNew(
 		m,
 		func(entry *wire.OneWayCallEntryMessage) restate.Void {
 			if entry.ServiceName != service ||
 				entry.Key != key ||
 				entry.HandlerName != method ||
-				!headersEqual(entry.Headers, headers) ||
+				entry.GetIdempotencyKey() != idempotencyKey ||
+				!headersEqual(withoutTraceContext(entry.Headers, headers), headers) ||
 				!bytes.Equal(entry.Parameter, body) {
 				panic(m.newEntryMismatch(&wire.OneWayCallEntryMessage{
 					OneWayCallEntryMessage: protocol.OneWayCallEntryMessage{
-						ServiceName: service,
-						HandlerName: method,
-						Headers:     headers,
-						Parameter:   body,
-						Key:         key,
+						ServiceName:    service,
+						HandlerName:    method,
+						Headers:        headers,
+						Parameter:      body,
+						Key:            key,
+						IdempotencyKey: optionalString(idempotencyKey),
+						Name:           name,
 					},
 				}, entry))
 			}
@@ -174,26 +258,69 @@ func (m *Machine) sendCall(service, key, method string, headersMap map[string]st
 			return restate.Void{}
 		},
 		func() restate.Void {
-			m._sendCall(service, key, method, headers, body, delay)
+			m._sendCall(service, key, method, name, idempotencyKey, headers, body, invokeTime())
 			return restate.Void{}
 		},
 	)
+	return entryIndex
 }
 
-func (c *Machine) _sendCall(service, key, method string, headers []*protocol.Header, params []byte, delay time.Duration) {
+func (c *Machine) _sendCall(service, key, method, name, idempotencyKey string, headers []*protocol.Header, params []byte, at time.Time) {
 	var invokeTime uint64
-	if delay != 0 {
-		invokeTime = uint64(time.Now().Add(delay).UnixMilli())
+	if !at.IsZero() {
+		invokeTime = uint64(at.UnixMilli())
 	}
 
 	c.Write(&wire.OneWayCallEntryMessage{
 		OneWayCallEntryMessage: protocol.OneWayCallEntryMessage{
-			ServiceName: service,
-			HandlerName: method,
-			Headers:     headers,
-			Parameter:   params,
-			Key:         key,
-			InvokeTime:  invokeTime,
+			ServiceName:    service,
+			HandlerName:    method,
+			Headers:        c.injectTraceContext(headers),
+			Parameter:      params,
+			Key:            key,
+			InvokeTime:     invokeTime,
+			IdempotencyKey: optionalString(idempotencyKey),
+			Name:           name,
 		},
 	})
 }
+
+func (m *Machine) getCallInvocationId(callEntryIndex uint32) (string, error) {
+	if err := m.requireProtocolVersion(protocol.ServiceProtocolVersion_V2, "getting the invocation ID of a call"); err != nil {
+		return "", err
+	}
+
+	entry, entryIndex := replayOrNew(
+		m,
+		func(entry *wire.GetCallInvocationIdEntryMessage) *wire.GetCallInvocationIdEntryMessage {
+			if entry.CallEntryIndex != callEntryIndex {
+				panic(m.newEntryMismatch(&wire.GetCallInvocationIdEntryMessage{
+					GetCallInvocationIdEntryMessage: protocol.GetCallInvocationIdEntryMessage{
+						CallEntryIndex: callEntryIndex,
+					},
+				}, entry))
+			}
+			return entry
+		},
+		func() *wire.GetCallInvocationIdEntryMessage {
+			msg := &wire.GetCallInvocationIdEntryMessage{
+				GetCallInvocationIdEntryMessage: protocol.GetCallInvocationIdEntryMessage{
+					CallEntryIndex: callEntryIndex,
+				},
+			}
+			m.Write(msg)
+			return msg
+		},
+	)
+
+	entry.Await(m.suspensionCtx, entryIndex)
+
+	switch result := entry.Result.(type) {
+	case *protocol.GetCallInvocationIdEntryMessage_Value:
+		return result.Value, nil
+	case *protocol.GetCallInvocationIdEntryMessage_Failure:
+		return "", errors.ErrorFromFailure(result.Failure)
+	default:
+		panic(m.newProtocolViolation(entry, fmt.Errorf("get call invocation id entry had invalid result: %v", entry.Result)))
+	}
+}
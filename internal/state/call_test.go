@@ -0,0 +1,27 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendAt(t *testing.T) {
+	at := time.Date(2030, time.January, 1, 9, 0, 0, 0, time.UTC)
+	handler := restate.NewServiceHandler(func(ctx restate.Context, _ restate.Void) (restate.Void, error) {
+		return restate.Void{}, restate.SendAs[string](ctx.Service("Greeter", "greet")).SendAt("bob", at)
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	var runtime restatetest.Runtime
+	result := runtime.Complete(t, handler, nil)
+
+	send, ok := result.Journal[0].(*protocol.OneWayCallEntryMessage)
+	require.True(t, ok)
+	require.Equal(t, uint64(at.UnixMilli()), send.InvokeTime)
+	require.Equal(t, restatetest.JSON(t, "bob"), send.Parameter)
+}
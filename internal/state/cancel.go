@@ -0,0 +1,67 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/internal/wire"
+	"google.golang.org/protobuf/proto"
+)
+
+// requireProtocolVersion returns an error if the negotiated service protocol version is older than version
+func (m *Machine) requireProtocolVersion(version protocol.ServiceProtocolVersion, feature string) error {
+	if m.protocolVersion < version {
+		return fmt.Errorf("%s requires service protocol %s, but %s was negotiated with Restate", feature, version, m.protocolVersion)
+	}
+	return nil
+}
+
+func (m *Machine) cancelCall(callEntryIndex uint32) error {
+	if err := m.requireProtocolVersion(protocol.ServiceProtocolVersion_V2, "cancelling a call"); err != nil {
+		return err
+	}
+
+	m.cancelInvocation(func() *wire.CancelInvocationEntryMessage {
+		return &wire.CancelInvocationEntryMessage{
+			CancelInvocationEntryMessage: protocol.CancelInvocationEntryMessage{
+				Target: &protocol.CancelInvocationEntryMessage_CallEntryIndex{CallEntryIndex: callEntryIndex},
+			},
+		}
+	})
+	return nil
+}
+
+func (m *Machine) cancelInvocationId(invocationId string) error {
+	if err := m.requireProtocolVersion(protocol.ServiceProtocolVersion_V2, "cancelling an invocation"); err != nil {
+		return err
+	}
+
+	m.cancelInvocation(func() *wire.CancelInvocationEntryMessage {
+		return &wire.CancelInvocationEntryMessage{
+			CancelInvocationEntryMessage: protocol.CancelInvocationEntryMessage{
+				Target: &protocol.CancelInvocationEntryMessage_InvocationId{InvocationId: invocationId},
+			},
+		}
+	})
+	return nil
+}
+
+// cancelInvocation journals the cancellation entry produced by newEntry, or checks it against the replayed entry
+func (m *Machine) cancelInvocation(newEntry func() *wire.CancelInvocationEntryMessage) {
+	_, _ = replayOrNew(
+		m,
+		func(entry *wire.CancelInvocationEntryMessage) *wire.CancelInvocationEntryMessage {
+			if expected := newEntry(); !proto.Equal(&entry.CancelInvocationEntryMessage, &expected.CancelInvocationEntryMessage) {
+				panic(m.newEntryMismatch(expected, entry))
+			}
+
+			return entry
+		},
+		func() *wire.CancelInvocationEntryMessage {
+			msg := newEntry()
+			m.Write(msg)
+
+			return msg
+		},
+	)
+}
@@ -0,0 +1,84 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelInvocation(t *testing.T) {
+	handler := restate.NewServiceHandler(func(ctx restate.Context, _ restate.Void) (string, error) {
+		handle, err := ctx.Service("Greeter", "greet").SendWithHandle("bob", time.Minute)
+		if err != nil {
+			return "", err
+		}
+		id, err := handle.InvocationId()
+		if err != nil {
+			return "", err
+		}
+		if err := ctx.CancelInvocation(id); err != nil {
+			return "", err
+		}
+
+		_, err = restate.CallAs[string](ctx.Service("Greeter", "greet")).Request("alice")
+		if !errors.Is(err, restate.ErrCancelled) {
+			return "", fmt.Errorf("expected a cancellation, got %v", err)
+		}
+		return id, nil
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	runtime := restatetest.Runtime{
+		Call: func(target restatetest.Target, input []byte) *restatetest.Completion {
+			return restatetest.Failure(restate.ErrCancelled)
+		},
+	}
+	result := runtime.Complete(t, handler, nil)
+	require.NoError(t, result.TerminalError)
+
+	id := restatetest.CallInvocationID(restatetest.DefaultInvocationID, 1)
+	require.Equal(t, restatetest.JSON(t, id), result.Output)
+	require.IsType(t, &protocol.OneWayCallEntryMessage{}, result.Journal[0])
+	require.IsType(t, &protocol.GetCallInvocationIdEntryMessage{}, result.Journal[1])
+	cancel, ok := result.Journal[2].(*protocol.CancelInvocationEntryMessage)
+	require.True(t, ok)
+	require.Equal(t, id, cancel.GetInvocationId())
+}
+
+func TestCancelCall(t *testing.T) {
+	handler := restate.NewServiceHandler(func(ctx restate.Context, _ restate.Void) (restate.Void, error) {
+		future, err := ctx.Service("Greeter", "greet").RequestFuture("bob")
+		if err != nil {
+			return restate.Void{}, err
+		}
+		return restate.Void{}, future.Cancel()
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	t.Run("v2", func(t *testing.T) {
+		var runtime restatetest.Runtime
+		result := runtime.Complete(t, handler, nil)
+		require.NoError(t, result.TerminalError)
+
+		require.Len(t, result.Journal, 3)
+		require.IsType(t, &protocol.CallEntryMessage{}, result.Journal[0])
+		cancel, ok := result.Journal[1].(*protocol.CancelInvocationEntryMessage)
+		require.True(t, ok)
+		require.Equal(t, uint32(1), cancel.GetCallEntryIndex())
+	})
+
+	t.Run("v1", func(t *testing.T) {
+		runtime := restatetest.Runtime{ProtocolVersion: protocol.ServiceProtocolVersion_V1}
+		result, err := runtime.Invoke(context.Background(), handler, nil)
+		require.NoError(t, err)
+		require.False(t, result.Completed())
+		require.ErrorContains(t, result.Error, "requires service protocol V2")
+	})
+}
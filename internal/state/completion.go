@@ -41,10 +41,15 @@ func (m *Machine) Write(message wire.Message) {
 		m.pendingMutex.Unlock()
 	}
 	typ := wire.MessageType(message)
-	m.log.LogAttrs(m.ctx, log.LevelTrace, "Sending message to runtime", log.Stringer("type", typ))
+	if name := wire.EntryName(message); name != "" {
+		m.log.LogAttrs(m.ctx, slog.LevelDebug, "Sending named entry to runtime", log.Stringer("type", typ), slog.Uint64("entryIndex", uint64(m.entryIndex)), slog.String("entryName", name))
+	} else {
+		m.log.LogAttrs(m.ctx, log.LevelTrace, "Sending message to runtime", log.Stringer("type", typ))
+	}
 	if err := m.protocol.Write(typ, message); err != nil {
 		panic(m.newWriteError(message, err))
 	}
+	m.metrics.newEntries += 1
 }
 
 type writeError struct {
@@ -0,0 +1,68 @@
+package state
+
+import (
+	stderrors "errors"
+	"io"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/internal/errors"
+	"github.com/restatedev/sdk-go/internal/wire"
+	"github.com/restatedev/sdk-go/metrics"
+)
+
+// attemptMetrics is accumulated by the machine over an attempt; the server adds the service, handler and duration
+type attemptMetrics struct {
+	outcome     metrics.Outcome
+	errorCode   restate.Code
+	newEntries  int
+	runFailures int
+}
+
+// Metrics summarises the attempt. It should only be called once Start has returned.
+func (m *Machine) Metrics() metrics.Attempt {
+	return metrics.Attempt{
+		Outcome:         m.metrics.outcome,
+		ErrorCode:       m.metrics.errorCode,
+		ReplayedEntries: len(m.entries),
+		NewEntries:      m.metrics.newEntries,
+		RunFailures:     m.metrics.runFailures,
+	}
+}
+
+func (m *Machine) recordOutcome(outcome metrics.Outcome, code restate.Code) {
+	m.metrics.outcome = outcome
+	m.metrics.errorCode = code
+}
+
+// recordPanicOutcome records the outcome of an attempt which ended with a panic recovered by invoke
+func (m *Machine) recordPanicOutcome(recovered any) {
+	switch typ := recovered.(type) {
+	case *protocolViolation, *concurrentContextUse, *writeError:
+		m.recordOutcome(metrics.ProtocolViolation, errors.ErrProtocolViolation)
+	case *entryMismatch:
+		m.recordOutcome(metrics.JournalMismatch, errors.ErrJournalMismatch)
+	case *runFailure:
+		m.recordOutcome(metrics.RetryableFailure, restate.ErrorCode(typ.err))
+	case *wire.SuspensionPanic:
+		if m.ctx.Err() != nil {
+			m.recordOutcome(metrics.Cancelled, 0)
+		} else if stderrors.Is(typ.Err, io.EOF) {
+			m.recordOutcome(metrics.Suspended, 0)
+		} else {
+			m.recordOutcome(metrics.RetryableFailure, restate.ErrorCode(typ.Err))
+		}
+	default:
+		m.recordOutcome(metrics.RetryableFailure, 500)
+	}
+}
+
+// countRunFailures wraps the function of a Run, counting every execution which returns an error
+func (m *Machine) countRunFailures(fn func(restate.RunContext) ([]byte, error)) func(restate.RunContext) ([]byte, error) {
+	return func(ctx restate.RunContext) ([]byte, error) {
+		bytes, err := fn(ctx)
+		if err != nil {
+			m.metrics.runFailures += 1
+		}
+		return bytes, err
+	}
+}
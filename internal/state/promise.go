@@ -0,0 +1,210 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/encoding"
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/internal/errors"
+	"github.com/restatedev/sdk-go/internal/futures"
+	"github.com/restatedev/sdk-go/internal/wire"
+)
+
+type decodingPromise struct {
+	*futures.Promise
+	machine *Machine
+	name    string
+	codec   encoding.Codec
+}
+
+func (d decodingPromise) Result(output any) (err error) {
+	if err := d.machine.awaitDeadline(d.Promise); err != nil {
+		return err
+	}
+	bytes, err := d.Promise.Result()
+	if err != nil {
+		return err
+	}
+	if err := encoding.Unmarshal(d.codec, bytes, output); err != nil {
+		return errors.NewTerminalError(fmt.Errorf("failed to unmarshal Promise result into output: %w", err))
+	}
+	return
+}
+
+func (d decodingPromise) Peek(output any) (ok bool, err error) {
+	bytes, ok, err := d.machine.peekPromise(d.name)
+	if err != nil || !ok {
+		return ok, err
+	}
+	if err := encoding.Unmarshal(d.codec, bytes, output); err != nil {
+		return false, errors.NewTerminalError(fmt.Errorf("failed to unmarshal Promise result into output: %w", err))
+	}
+	return true, nil
+}
+
+func (d decodingPromise) Resolve(value any) error {
+	bytes, err := encoding.Marshal(d.codec, value)
+	if err != nil {
+		return errors.NewTerminalError(fmt.Errorf("failed to marshal Promise Resolve value: %w", err))
+	}
+	return d.machine.resolvePromise(d.name, bytes)
+}
+
+func (d decodingPromise) Reject(reason error) error {
+	return d.machine.rejectPromise(d.name, reason)
+}
+
+func (m *Machine) getPromise(key string) (*wire.GetPromiseEntryMessage, uint32) {
+	return replayOrNew(
+		m,
+		func(entry *wire.GetPromiseEntryMessage) *wire.GetPromiseEntryMessage {
+			if entry.Key != key {
+				panic(m.newEntryMismatch(&wire.GetPromiseEntryMessage{
+					GetPromiseEntryMessage: protocol.GetPromiseEntryMessage{
+						Key: key,
+					},
+				}, entry))
+			}
+			return entry
+		}, func() *wire.GetPromiseEntryMessage {
+			return m._getPromise(key)
+		})
+}
+
+func (m *Machine) _getPromise(key string) *wire.GetPromiseEntryMessage {
+	msg := &wire.GetPromiseEntryMessage{
+		GetPromiseEntryMessage: protocol.GetPromiseEntryMessage{
+			Key: key,
+		},
+	}
+	m.Write(msg)
+
+	return msg
+}
+
+func (m *Machine) peekPromise(key string) ([]byte, bool, error) {
+	entry, entryIndex := replayOrNew(
+		m,
+		func(entry *wire.PeekPromiseEntryMessage) *wire.PeekPromiseEntryMessage {
+			if entry.Key != key {
+				panic(m.newEntryMismatch(&wire.PeekPromiseEntryMessage{
+					PeekPromiseEntryMessage: protocol.PeekPromiseEntryMessage{
+						Key: key,
+					},
+				}, entry))
+			}
+			return entry
+		}, func() *wire.PeekPromiseEntryMessage {
+			return m._peekPromise(key)
+		})
+
+	entry.Await(m.suspensionCtx, entryIndex)
+
+	switch result := entry.Result.(type) {
+	case *protocol.PeekPromiseEntryMessage_Empty:
+		return nil, false, nil
+	case *protocol.PeekPromiseEntryMessage_Value:
+		return result.Value, true, nil
+	case *protocol.PeekPromiseEntryMessage_Failure:
+		return nil, true, errors.ErrorFromFailure(result.Failure)
+	default:
+		panic(m.newProtocolViolation(entry, fmt.Errorf("peek promise entry had invalid result: %v", entry.Result)))
+	}
+}
+
+func (m *Machine) _peekPromise(key string) *wire.PeekPromiseEntryMessage {
+	msg := &wire.PeekPromiseEntryMessage{
+		PeekPromiseEntryMessage: protocol.PeekPromiseEntryMessage{
+			Key: key,
+		},
+	}
+	m.Write(msg)
+
+	return msg
+}
+
+func (m *Machine) resolvePromise(key string, value []byte) error {
+	entry, entryIndex := replayOrNew(
+		m,
+		func(entry *wire.CompletePromiseEntryMessage) *wire.CompletePromiseEntryMessage {
+			messageValue, ok := entry.Completion.(*protocol.CompletePromiseEntryMessage_CompletionValue)
+			if entry.Key != key || !ok || !bytes.Equal(messageValue.CompletionValue, value) {
+				panic(m.newEntryMismatch(&wire.CompletePromiseEntryMessage{
+					CompletePromiseEntryMessage: protocol.CompletePromiseEntryMessage{
+						Key:        key,
+						Completion: &protocol.CompletePromiseEntryMessage_CompletionValue{CompletionValue: value},
+					},
+				}, entry))
+			}
+			return entry
+		}, func() *wire.CompletePromiseEntryMessage {
+			return m._resolvePromise(key, value)
+		})
+
+	return m.awaitCompletePromise(entry, entryIndex)
+}
+
+func (m *Machine) rejectPromise(key string, reason error) error {
+	failure := &protocol.Failure{
+		Code:    uint32(restate.ErrorCode(reason)),
+		Message: reason.Error(),
+	}
+
+	entry, entryIndex := replayOrNew(
+		m,
+		func(entry *wire.CompletePromiseEntryMessage) *wire.CompletePromiseEntryMessage {
+			messageFailure, ok := entry.Completion.(*protocol.CompletePromiseEntryMessage_CompletionFailure)
+			if entry.Key != key || !ok || messageFailure.CompletionFailure.Code != failure.Code || messageFailure.CompletionFailure.Message != failure.Message {
+				panic(m.newEntryMismatch(&wire.CompletePromiseEntryMessage{
+					CompletePromiseEntryMessage: protocol.CompletePromiseEntryMessage{
+						Key:        key,
+						Completion: &protocol.CompletePromiseEntryMessage_CompletionFailure{CompletionFailure: failure},
+					},
+				}, entry))
+			}
+			return entry
+		}, func() *wire.CompletePromiseEntryMessage {
+			return m._rejectPromise(key, failure)
+		})
+
+	return m.awaitCompletePromise(entry, entryIndex)
+}
+
+func (m *Machine) _resolvePromise(key string, value []byte) *wire.CompletePromiseEntryMessage {
+	msg := &wire.CompletePromiseEntryMessage{
+		CompletePromiseEntryMessage: protocol.CompletePromiseEntryMessage{
+			Key:        key,
+			Completion: &protocol.CompletePromiseEntryMessage_CompletionValue{CompletionValue: value},
+		},
+	}
+	m.Write(msg)
+
+	return msg
+}
+
+func (m *Machine) _rejectPromise(key string, failure *protocol.Failure) *wire.CompletePromiseEntryMessage {
+	msg := &wire.CompletePromiseEntryMessage{
+		CompletePromiseEntryMessage: protocol.CompletePromiseEntryMessage{
+			Key:        key,
+			Completion: &protocol.CompletePromiseEntryMessage_CompletionFailure{CompletionFailure: failure},
+		},
+	}
+	m.Write(msg)
+
+	return msg
+}
+
+func (m *Machine) awaitCompletePromise(entry *wire.CompletePromiseEntryMessage, entryIndex uint32) error {
+	entry.Await(m.suspensionCtx, entryIndex)
+
+	switch result := entry.Result.(type) {
+	case *protocol.CompletePromiseEntryMessage_Empty:
+		return nil
+	case *protocol.CompletePromiseEntryMessage_Failure:
+		return errors.ErrorFromFailure(result.Failure)
+	default:
+		panic(m.newProtocolViolation(entry, fmt.Errorf("complete promise entry had invalid result: %v", entry.Result)))
+	}
+}
@@ -0,0 +1,36 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowPromise(t *testing.T) {
+	run := restate.NewWorkflowHandler(func(ctx restate.WorkflowContext, _ restate.Void) (string, error) {
+		return restate.PromiseAs[string](ctx, "approval").Result()
+	})
+	approve := restate.NewWorkflowSharedHandler(func(ctx restate.WorkflowSharedContext, decision string) (restate.Void, error) {
+		return restate.Void{}, restate.PromiseAs[string](ctx, "approval").Resolve(decision)
+	})
+	restate.NewWorkflow("Approval").Handler("run", run).Handler("approve", approve)
+
+	runtime := restatetest.Runtime{
+		Key: "wf-1",
+		Promises: map[string]*restatetest.Completion{
+			"approval": restatetest.Value(restatetest.JSON(t, "approved")),
+		},
+	}
+
+	result := runtime.Complete(t, run, nil)
+	require.Equal(t, restatetest.JSON(t, "approved"), result.Output)
+
+	result, err := runtime.Invoke(context.Background(), approve, restatetest.JSON(t, "rejected"))
+	require.NoError(t, err)
+	require.True(t, result.Completed())
+	require.Error(t, result.TerminalError)
+	require.EqualValues(t, 409, restate.ErrorCode(result.TerminalError))
+}
@@ -15,12 +15,14 @@ import (
 	restate "github.com/restatedev/sdk-go"
 	"github.com/restatedev/sdk-go/encoding"
 	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/internal"
 	"github.com/restatedev/sdk-go/internal/errors"
 	"github.com/restatedev/sdk-go/internal/futures"
 	"github.com/restatedev/sdk-go/internal/log"
 	"github.com/restatedev/sdk-go/internal/options"
 	"github.com/restatedev/sdk-go/internal/rand"
 	"github.com/restatedev/sdk-go/internal/wire"
+	"github.com/restatedev/sdk-go/metrics"
 	"github.com/restatedev/sdk-go/rcontext"
 )
 
@@ -39,6 +41,8 @@ type Context struct {
 }
 
 var _ restate.ObjectContext = &Context{}
+var _ restate.WorkflowContext = &Context{}
+var _ restate.WorkflowSharedContext = &Context{}
 var _ restate.ObjectSharedContext = &Context{}
 var _ restate.Context = &Context{}
 var _ restate.RunContext = &Context{}
@@ -109,12 +113,40 @@ func (c *Context) Keys() []string {
 	return c.machine.keys()
 }
 
-func (c *Context) Sleep(d time.Duration) error {
-	return c.machine.sleep(d)
+func (c *Context) Prefetch(keys ...string) {
+	c.machine.prefetch(keys)
 }
 
-func (c *Context) After(d time.Duration) restate.After {
-	return c.machine.after(d)
+func (c *Context) Sleep(d time.Duration, opts ...options.SleepOption) error {
+	o := options.SleepOptions{}
+	for _, opt := range opts {
+		opt.BeforeSleep(&o)
+	}
+	return c.machine.sleep(d, o.Name)
+}
+
+func (c *Context) After(d time.Duration, opts ...options.SleepOption) restate.After {
+	o := options.SleepOptions{}
+	for _, opt := range opts {
+		opt.BeforeSleep(&o)
+	}
+	return after{c.machine.after(d, o.Name), c.machine}
+}
+
+func (c *Context) Timer(d time.Duration, opts ...options.SleepOption) restate.Timer {
+	o := options.SleepOptions{}
+	for _, opt := range opts {
+		opt.BeforeSleep(&o)
+	}
+	return c.machine.timer(d, o.Name)
+}
+
+func (c *Context) WithDeadline(deadline time.Time, opts ...options.SleepOption) {
+	o := options.SleepOptions{}
+	for _, opt := range opts {
+		opt.BeforeSleep(&o)
+	}
+	c.machine.setDeadline(deadline, o.Name)
 }
 
 func (c *Context) Service(service, method string, opts ...options.CallOption) restate.CallClient {
@@ -173,7 +205,7 @@ func (c *Context) Run(fn func(ctx restate.RunContext) (any, error), output any,
 		}
 
 		return bytes, nil
-	})
+	}, o.Name, o.RetryPolicy)
 	if err != nil {
 		return err
 	}
@@ -201,16 +233,20 @@ func (c *Context) Awakeable(opts ...options.AwakeableOption) restate.Awakeable {
 	if o.Codec == nil {
 		o.Codec = encoding.JSONCodec
 	}
-	return decodingAwakeable{c.machine.awakeable(), o.Codec}
+	return decodingAwakeable{c.machine.awakeable(o.Name), c.machine, o.Codec}
 }
 
 type decodingAwakeable struct {
 	*futures.Awakeable
-	codec encoding.Codec
+	machine *Machine
+	codec   encoding.Codec
 }
 
 func (d decodingAwakeable) Id() string { return d.Awakeable.Id() }
 func (d decodingAwakeable) Result(output any) (err error) {
+	if err := d.machine.awaitDeadline(d.Awakeable); err != nil {
+		return err
+	}
 	bytes, err := d.Awakeable.Result()
 	if err != nil {
 		return err
@@ -241,6 +277,99 @@ func (c *Context) RejectAwakeable(id string, reason error) {
 	c.machine.rejectAwakeable(id, reason)
 }
 
+func (c *Context) Promise(name string, opts ...options.PromiseOption) restate.DurablePromise {
+	o := options.PromiseOptions{}
+	for _, opt := range opts {
+		opt.BeforePromise(&o)
+	}
+	if o.Codec == nil {
+		o.Codec = encoding.JSONCodec
+	}
+	return decodingPromise{
+		futures.NewPromise(c.machine.suspensionCtx, func() (*wire.GetPromiseEntryMessage, uint32) {
+			return c.machine.getPromise(name)
+		}, func(entry wire.Message, err error) any {
+			return c.machine.newProtocolViolation(entry, err)
+		}),
+		c.machine,
+		name,
+		o.Codec,
+	}
+}
+
+func (c *Context) CancelInvocation(invocationId string) error {
+	return c.machine.cancelInvocationId(invocationId)
+}
+
+func (c *Context) AttachInvocation(invocationId string, opts ...options.AttachOption) (restate.AttachFuture, error) {
+	o := attachOptions(opts)
+	fut, err := c.machine.attachInvocation(invocationId, o.Name)
+	if err != nil {
+		return nil, err
+	}
+	return decodingAttachFuture{fut, c.machine, o.Codec}, nil
+}
+
+func (c *Context) GetInvocationOutput(invocationId string, opts ...options.AttachOption) (restate.InvocationOutputFuture, error) {
+	o := attachOptions(opts)
+	fut, err := c.machine.getInvocationOutput(invocationId, o.Name)
+	if err != nil {
+		return nil, err
+	}
+	return decodingInvocationOutputFuture{fut, c.machine, o.Codec}, nil
+}
+
+func attachOptions(opts []options.AttachOption) options.AttachOptions {
+	o := options.AttachOptions{}
+	for _, opt := range opts {
+		opt.BeforeAttach(&o)
+	}
+	if o.Codec == nil {
+		o.Codec = encoding.JSONCodec
+	}
+	return o
+}
+
+type decodingAttachFuture struct {
+	*futures.AttachFuture
+	machine *Machine
+	codec   encoding.Codec
+}
+
+func (d decodingAttachFuture) Response(output any) error {
+	if err := d.machine.awaitDeadline(d.AttachFuture); err != nil {
+		return err
+	}
+	bytes, err := d.AttachFuture.Response()
+	if err != nil {
+		return err
+	}
+	if err := encoding.Unmarshal(d.codec, bytes, output); err != nil {
+		return errors.NewTerminalError(fmt.Errorf("failed to unmarshal attached invocation response into output: %w", err))
+	}
+	return nil
+}
+
+type decodingInvocationOutputFuture struct {
+	*futures.InvocationOutputFuture
+	machine *Machine
+	codec   encoding.Codec
+}
+
+func (d decodingInvocationOutputFuture) Output(output any) (bool, error) {
+	if err := d.machine.awaitDeadline(d.InvocationOutputFuture); err != nil {
+		return false, err
+	}
+	bytes, ok, err := d.InvocationOutputFuture.Output()
+	if !ok || err != nil {
+		return ok, err
+	}
+	if err := encoding.Unmarshal(d.codec, bytes, output); err != nil {
+		return true, errors.NewTerminalError(fmt.Errorf("failed to unmarshal invocation output into output: %w", err))
+	}
+	return true, nil
+}
+
 func (c *Context) Select(futs ...restate.Selectable) restate.Selector {
 	return c.machine.selector(futs...)
 }
@@ -265,16 +394,22 @@ type Machine struct {
 	ctx           context.Context
 	suspensionCtx context.Context
 	suspend       func(error)
+	// guards suspend, which is only set once the machine has started, against concurrent calls to Suspend
+	suspendMutex     sync.Mutex
+	suspendRequested bool
 
-	handler  restate.Handler
-	protocol *wire.Protocol
+	handler    restate.Handler
+	middleware []restate.Middleware
+	protocol   *wire.Protocol
+	// the service protocol version negotiated with the runtime
+	protocolVersion protocol.ServiceProtocolVersion
 
 	// state
 	key     string
 	request restate.Request
 
-	partial bool
-	current map[string][]byte
+	state      stateCache
+	prefetched map[string]prefetchedEntry
 
 	entries    []wire.Message
 	entryIndex uint32
@@ -290,13 +425,24 @@ type Machine struct {
 
 	rand *rand.Rand
 
+	// only provided by the runtime from V2
+	retryCountSinceLastStoredEntry uint32
+	durationSinceLastStoredEntry   time.Duration
+
+	metrics attemptMetrics
+
+	// the sleep entry of the deadline set with WithDeadline, if any
+	deadline *futures.After
+
 	failure any
 }
 
-func NewMachine(handler restate.Handler, conn io.ReadWriter, attemptHeaders map[string][]string) *Machine {
+func NewMachine(handler restate.Handler, conn io.ReadWriter, protocolVersion protocol.ServiceProtocolVersion, attemptHeaders map[string][]string) *Machine {
 	m := &Machine{
 		handler:            handler,
-		current:            make(map[string][]byte),
+		protocolVersion:    protocolVersion,
+		state:              newStateCache(),
+		prefetched:         map[string]prefetchedEntry{},
 		pendingAcks:        map[uint32]wire.AckableMessage{},
 		pendingCompletions: map[uint32]wire.CompleteableMessage{},
 		request: restate.Request{
@@ -309,6 +455,27 @@ func NewMachine(handler restate.Handler, conn io.ReadWriter, attemptHeaders map[
 
 func (m *Machine) Log() *slog.Logger { return m.log }
 
+// Use sets the server middleware which wraps the handler, outside of any service or handler middleware.
+// It must be called before Start.
+func (m *Machine) Use(middleware ...restate.Middleware) {
+	m.middleware = middleware
+}
+
+// ErrShuttingDown is the cause of a suspension requested with Suspend
+var ErrShuttingDown = fmt.Errorf("server is shutting down: %w", io.EOF)
+
+// Suspend makes the invocation suspend at its next blocking operation rather than waiting for completions from the
+// runtime, so that a shutting down server can hand the invocation back to Restate. It may be called at any time.
+func (m *Machine) Suspend() {
+	m.suspendMutex.Lock()
+	defer m.suspendMutex.Unlock()
+
+	m.suspendRequested = true
+	if m.suspend != nil {
+		m.suspend(ErrShuttingDown)
+	}
+}
+
 // Start starts the state machine
 func (m *Machine) Start(inner context.Context, dropReplayLogs bool, logHandler slog.Handler) error {
 	msg, _, err := m.protocol.Read()
@@ -323,10 +490,20 @@ func (m *Machine) Start(inner context.Context, dropReplayLogs bool, logHandler s
 	}
 
 	m.ctx = inner
+	m.suspendMutex.Lock()
 	m.suspensionCtx, m.suspend = context.WithCancelCause(m.ctx)
+	if m.suspendRequested {
+		m.suspend(ErrShuttingDown)
+	}
+	m.suspendMutex.Unlock()
 	m.request.ID = start.Id
 	m.rand = rand.New(m.request.ID)
 	m.key = start.Key
+	if m.protocolVersion >= protocol.ServiceProtocolVersion_V2 {
+		m.retryCountSinceLastStoredEntry = start.RetryCountSinceLastStoredEntry
+		m.durationSinceLastStoredEntry = time.Duration(start.DurationSinceLastStoredEntry) * time.Millisecond
+	}
+	m.recordStart(start)
 
 	logHandler = logHandler.WithAttrs([]slog.Attr{slog.String("invocationID", start.DebugId)})
 
@@ -348,19 +525,24 @@ func (m *Machine) invoke(ctx *Context, outputSeen bool) error {
 		// if there was a panic
 		//
 		recovered := recover()
+		if recovered != nil {
+			m.recordFailure(recovered)
+			m.recordPanicOutcome(recovered)
+		}
 
 		switch typ := recovered.(type) {
 		case nil:
 			// nothing to do, just exit
 			return
 		case *protocolViolation:
-			m.log.LogAttrs(m.ctx, slog.LevelError, "Protocol violation", log.Error(typ.err))
+			m.log.LogAttrs(m.ctx, slog.LevelError, "Protocol violation", log.Error(typ.err), slog.String("entryName", wire.EntryName(typ.entry)))
 
 			if err := m.protocol.Write(wire.ErrorMessageType, &wire.ErrorMessage{
 				ErrorMessage: protocol.ErrorMessage{
 					Code:              uint32(errors.ErrProtocolViolation),
 					Message:           fmt.Sprintf("Protocol violation: %v", typ.err),
 					RelatedEntryIndex: &typ.entryIndex,
+					RelatedEntryName:  relatedEntryName(wire.EntryName(typ.entry)),
 					RelatedEntryType:  wire.MessageType(typ.entry).UInt32(),
 				},
 			}); err != nil {
@@ -384,20 +566,19 @@ func (m *Machine) invoke(ctx *Context, outputSeen bool) error {
 
 			m.log.LogAttrs(m.ctx, slog.LevelError, "Journal mismatch: Replayed journal entries did not correspond to the user code. The user code has to be deterministic!",
 				log.Type("expectedType", typ.expectedEntry),
+				slog.String("expectedName", wire.EntryName(typ.expectedEntry)),
 				slog.String("expectedMessage", string(expected)),
 				log.Type("actualType", typ.actualEntry),
+				slog.String("actualName", wire.EntryName(typ.actualEntry)),
 				slog.String("actualMessage", string(actual)))
 
 			// journal entry mismatch
 			if err := m.protocol.Write(wire.ErrorMessageType, &wire.ErrorMessage{
 				ErrorMessage: protocol.ErrorMessage{
-					Code: uint32(errors.ErrJournalMismatch),
-					Message: fmt.Sprintf(`Journal mismatch: Replayed journal entries did not correspond to the user code. The user code has to be deterministic!
-The journal entry at position %d was:
-- In the user code: type: %T, message: %s
-- In the replayed messages: type: %T, message %s`,
-						typ.entryIndex, typ.expectedEntry, string(expected), typ.actualEntry, string(actual)),
+					Code:              uint32(errors.ErrJournalMismatch),
+					Message:           EntryMismatchMessage(typ.entryIndex, typ.expectedEntry, typ.actualEntry),
 					RelatedEntryIndex: &typ.entryIndex,
+					RelatedEntryName:  relatedEntryName(wire.EntryName(typ.actualEntry)),
 					RelatedEntryType:  wire.MessageType(typ.actualEntry).UInt32(),
 				},
 			}); err != nil {
@@ -413,20 +594,22 @@ The journal entry at position %d was:
 					Code:              uint32(errors.ErrProtocolViolation),
 					Message:           typ.err.Error(),
 					RelatedEntryIndex: &typ.entryIndex,
+					RelatedEntryName:  relatedEntryName(wire.EntryName(typ.entry)),
 					RelatedEntryType:  wire.MessageType(typ.entry).UInt32(),
 				},
 			})
 
 			return
 		case *runFailure:
-			m.log.LogAttrs(m.ctx, slog.LevelError, "Run returned a failure, returning error to Restate", log.Error(typ.err))
+			m.log.LogAttrs(m.ctx, slog.LevelError, "Run returned a failure, returning error to Restate", log.Error(typ.err), slog.String("entryName", typ.entryName))
 
 			if err := m.protocol.Write(wire.ErrorMessageType, &wire.ErrorMessage{
 				ErrorMessage: protocol.ErrorMessage{
 					Code:              uint32(restate.ErrorCode(typ.err)),
 					Message:           typ.err.Error(),
 					RelatedEntryIndex: &typ.entryIndex,
-					RelatedEntryType:  wire.AwakeableEntryMessageType.UInt32(),
+					RelatedEntryName:  relatedEntryName(typ.entryName),
+					RelatedEntryType:  wire.RunEntryMessageType.UInt32(),
 				},
 			}); err != nil {
 				m.log.LogAttrs(m.ctx, slog.LevelError, "Error sending failure message", log.Error(typ.err))
@@ -484,21 +667,20 @@ The journal entry at position %d was:
 
 	if outputSeen {
 		m.log.WarnContext(m.ctx, "Invocation already completed; ending immediately")
+		m.recordOutcome(metrics.Success, 0)
 
 		return m.protocol.Write(wire.EndMessageType, &wire.EndMessage{})
 	}
 
-	var bytes []byte
-	var err error
-	switch handler := m.handler.(type) {
-	case restate.ObjectHandler:
-		bytes, err = handler.Call(ctx, m.request.Body)
-	case restate.ServiceHandler:
-		bytes, err = handler.Call(ctx, m.request.Body)
+	bytes, err := restate.Chain(m.call, m.middleware...)(ctx, m.request.Body)
+
+	if err != nil {
+		m.recordFailure(err)
 	}
 
 	if err != nil && restate.IsTerminalError(err) {
 		m.log.LogAttrs(m.ctx, slog.LevelError, "Invocation returned a terminal failure", log.Error(err))
+		m.recordOutcome(metrics.TerminalFailure, restate.ErrorCode(err))
 
 		// terminal errors.
 		if err := m.protocol.Write(wire.OutputEntryMessageType, &wire.OutputEntryMessage{
@@ -516,6 +698,7 @@ The journal entry at position %d was:
 		return m.protocol.Write(wire.EndMessageType, &wire.EndMessage{})
 	} else if err != nil {
 		m.log.LogAttrs(m.ctx, slog.LevelError, "Invocation returned a non-terminal failure", log.Error(err))
+		m.recordOutcome(metrics.RetryableFailure, restate.ErrorCode(err))
 
 		// non terminal error - no end message
 		return m.protocol.Write(wire.ErrorMessageType, &wire.ErrorMessage{
@@ -526,6 +709,7 @@ The journal entry at position %d was:
 		})
 	} else {
 		m.log.InfoContext(m.ctx, "Invocation completed successfully")
+		m.recordOutcome(metrics.Success, 0)
 
 		if err := m.protocol.Write(wire.OutputEntryMessageType, &wire.OutputEntryMessage{
 			OutputEntryMessage: protocol.OutputEntryMessage{
@@ -541,11 +725,24 @@ The journal entry at position %d was:
 	}
 }
 
+// call is the innermost handler of the server middleware chain
+func (m *Machine) call(ctx restate.Context, input []byte) ([]byte, error) {
+	switch handler := m.handler.(type) {
+	case restate.ObjectHandler:
+		return handler.Call(internal.ContextAs[restate.ObjectContext](ctx), input)
+	case restate.ServiceHandler:
+		return handler.Call(ctx, input)
+	case restate.WorkflowHandler:
+		return handler.Call(internal.ContextAs[restate.WorkflowContext](ctx), input)
+	}
+	return nil, nil
+}
+
 func (m *Machine) process(ctx *Context, start *wire.StartMessage) error {
 	for _, entry := range start.StateMap {
-		m.current[string(entry.Key)] = entry.Value
+		m.state.set(string(entry.Key), entry.Value)
 	}
-	m.partial = start.PartialState
+	m.state.partial = start.PartialState
 
 	// expect input message
 	msg, _, err := m.protocol.Read()
@@ -608,6 +805,15 @@ func (c *Machine) currentEntry() (wire.Message, bool) {
 	return nil, false
 }
 
+// nextEntry returns the entry that the next call to replayOrNew will replay, if there is one
+func (c *Machine) nextEntry() (wire.Message, bool) {
+	if c.entryIndex < uint32(len(c.entries)) {
+		return c.entries[c.entryIndex], true
+	}
+
+	return nil, false
+}
+
 // replayOrNew is a utility function to easily either
 // replay a log entry, or create a new one if one
 // does not exist
@@ -3,15 +3,17 @@ package state
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
-	"sort"
 	"time"
 
 	restate "github.com/restatedev/sdk-go"
 	"github.com/restatedev/sdk-go/generated/proto/protocol"
 	"github.com/restatedev/sdk-go/internal/errors"
 	"github.com/restatedev/sdk-go/internal/futures"
+	"github.com/restatedev/sdk-go/internal/log"
+	"github.com/restatedev/sdk-go/internal/options"
 	"github.com/restatedev/sdk-go/internal/wire"
 	"google.golang.org/protobuf/proto"
 )
@@ -29,6 +31,34 @@ func (m *Machine) newEntryMismatch(expectedEntry wire.Message, actualEntry wire.
 	return e
 }
 
+// EntryMismatchMessage describes the mismatch at entryIndex between the entry produced by the user code
+// and the entry that was replayed.
+func EntryMismatchMessage(entryIndex uint32, expectedEntry wire.Message, actualEntry wire.Message) string {
+	expected, _ := json.Marshal(expectedEntry)
+	actual, _ := json.Marshal(actualEntry)
+
+	return fmt.Sprintf(`Journal mismatch: Replayed journal entries did not correspond to the user code. The user code has to be deterministic!
+The journal entry at position %d was:
+- In the user code: type: %T%s, message: %s
+- In the replayed messages: type: %T%s, message %s`,
+		entryIndex, expectedEntry, describeName(expectedEntry), string(expected), actualEntry, describeName(actualEntry), string(actual))
+}
+
+func describeName(entry wire.Message) string {
+	if name := wire.EntryName(entry); name != "" {
+		return fmt.Sprintf(", name: %q", name)
+	}
+	return ""
+}
+
+// relatedEntryName returns the name of an entry for an error message, if it has one
+func relatedEntryName(name string) *string {
+	if name == "" {
+		return nil
+	}
+	return &name
+}
+
 type protocolViolation struct {
 	entryIndex uint32
 	entry      wire.Message
@@ -59,7 +89,8 @@ func (m *Machine) set(key string, value []byte) {
 			return void
 		})
 
-	m.current[key] = value
+	m.state.set(key, value)
+	delete(m.prefetched, key)
 }
 
 func (m *Machine) _set(key string, value []byte) {
@@ -91,7 +122,8 @@ func (m *Machine) clear(key string) {
 		},
 	)
 
-	delete(m.current, key)
+	m.state.clear(key)
+	delete(m.prefetched, key)
 }
 
 func (m *Machine) _clear(key string) {
@@ -114,8 +146,8 @@ func (m *Machine) clearAll() {
 			return restate.Void{}
 		},
 	)
-	m.current = map[string][]byte{}
-	m.partial = false
+	m.state.clearAll()
+	m.prefetched = map[string]prefetchedEntry{}
 }
 
 // clearAll drops all associated keys
@@ -126,6 +158,12 @@ func (m *Machine) _clearAll() {
 }
 
 func (m *Machine) get(key string) []byte {
+	if prefetched, ok := m.prefetched[key]; ok {
+		// the lookup was already journaled by prefetch
+		delete(m.prefetched, key)
+		return m.getResult(key, prefetched.entry, prefetched.entryIndex)
+	}
+
 	entry, entryIndex := replayOrNew(
 		m,
 		func(entry *wire.GetStateEntryMessage) *wire.GetStateEntryMessage {
@@ -141,13 +179,18 @@ func (m *Machine) get(key string) []byte {
 			return m._get(key)
 		})
 
+	return m.getResult(key, entry, entryIndex)
+}
+
+func (m *Machine) getResult(key string, entry *wire.GetStateEntryMessage, entryIndex uint32) []byte {
 	entry.Await(m.suspensionCtx, entryIndex)
 
 	switch value := entry.Result.(type) {
 	case *protocol.GetStateEntryMessage_Empty:
+		m.state.fill(key, nil)
 		return nil
 	case *protocol.GetStateEntryMessage_Value:
-		m.current[key] = value.Value
+		m.state.fill(key, value.Value)
 		return value.Value
 	default:
 		panic(m.newProtocolViolation(entry, fmt.Errorf("get state entry had invalid result: %v", entry.Result)))
@@ -161,23 +204,13 @@ func (m *Machine) _get(key string) *wire.GetStateEntryMessage {
 		},
 	}
 
-	value, ok := m.current[key]
-
-	if ok {
-		// value in map, we still send the current
-		// value to the runtime
-		msg.Complete(&protocol.CompletionMessage{Result: &protocol.CompletionMessage_Value{Value: value}})
-
-		m.Write(msg)
-
-		return msg
-	}
-
-	// key is not in map! there are 2 cases.
-	if !m.partial {
-		// current is complete. we need to return nil to the user
-		// but also send an empty get state entry message
-		msg.Complete(&protocol.CompletionMessage{Result: &protocol.CompletionMessage_Empty{Empty: &protocol.Empty{}}})
+	if value, ok := m.state.get(key); ok {
+		// we know the value (or that there is none); we still send it to the runtime
+		if value != nil {
+			msg.Complete(&protocol.CompletionMessage{Result: &protocol.CompletionMessage_Value{Value: value}})
+		} else {
+			msg.Complete(&protocol.CompletionMessage{Result: &protocol.CompletionMessage_Empty{Empty: &protocol.Empty{}}})
+		}
 
 		m.Write(msg)
 
@@ -191,6 +224,38 @@ func (m *Machine) _get(key string) *wire.GetStateEntryMessage {
 	return msg
 }
 
+type prefetchedEntry struct {
+	entry      *wire.GetStateEntryMessage
+	entryIndex uint32
+}
+
+// prefetch journals lookups of keys without waiting for their results, so that the runtime can fetch them
+// concurrently. The next get of each key uses its prefetched entry rather than journaling another.
+func (m *Machine) prefetch(keys []string) {
+	for _, key := range keys {
+		if _, ok := m.prefetched[key]; ok {
+			continue
+		}
+
+		entry, entryIndex := replayOrNew(
+			m,
+			func(entry *wire.GetStateEntryMessage) *wire.GetStateEntryMessage {
+				if string(entry.Key) != key {
+					panic(m.newEntryMismatch(&wire.GetStateEntryMessage{
+						GetStateEntryMessage: protocol.GetStateEntryMessage{
+							Key: []byte(key),
+						},
+					}, entry))
+				}
+				return entry
+			}, func() *wire.GetStateEntryMessage {
+				return m._get(key)
+			})
+
+		m.prefetched[key] = prefetchedEntry{entry, entryIndex}
+	}
+}
+
 func (m *Machine) keys() []string {
 	entry, entryIndex := replayOrNew(
 		m,
@@ -217,13 +282,7 @@ func (m *Machine) keys() []string {
 
 func (m *Machine) _keys() *wire.GetStateKeysEntryMessage {
 	msg := &wire.GetStateKeysEntryMessage{}
-	if !m.partial {
-		keys := make([]string, 0, len(m.current))
-		for k := range m.current {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
-
+	if keys, ok := m.state.keys(); ok {
 		byteKeys := make([][]byte, len(keys))
 		for i := range keys {
 			byteKeys[i] = []byte(keys[i])
@@ -246,44 +305,53 @@ func (m *Machine) _keys() *wire.GetStateKeysEntryMessage {
 	return msg
 }
 
-func (m *Machine) after(d time.Duration) *futures.After {
+func (m *Machine) after(d time.Duration, name string) *futures.After {
+	return m.afterTime(func() time.Time { return time.Now().Add(d) }, name)
+}
+
+// afterTime journals a sleep until the time returned by wakeUpTime, which is only called for a new entry
+func (m *Machine) afterTime(wakeUpTime func() time.Time, name string) *futures.After {
 	entry, entryIndex := replayOrNew(
 		m,
 		func(entry *wire.SleepEntryMessage) *wire.SleepEntryMessage {
 			// we shouldn't verify the time because this would be different every time
+			m.recordReplay("sleep", entry)
 			return entry
 		}, func() *wire.SleepEntryMessage {
-			return m._sleep(d)
+			return m._sleep(wakeUpTime(), name)
 		},
 	)
 
 	return futures.NewAfter(m.suspensionCtx, entry, entryIndex)
 }
 
-func (m *Machine) sleep(d time.Duration) error {
-	return m.after(d).Done()
+func (m *Machine) sleep(d time.Duration, name string) error {
+	return after{m.after(d, name), m}.Done()
 }
 
 // _sleep creating a new sleep entry.
-func (m *Machine) _sleep(d time.Duration) *wire.SleepEntryMessage {
+func (m *Machine) _sleep(wakeUpTime time.Time, name string) *wire.SleepEntryMessage {
 	msg := &wire.SleepEntryMessage{
 		SleepEntryMessage: protocol.SleepEntryMessage{
-			WakeUpTime: uint64(time.Now().Add(d).UnixMilli()),
+			WakeUpTime: uint64(wakeUpTime.UnixMilli()),
+			Name:       name,
 		},
 	}
 	m.Write(msg)
+	m.recordSleep(name, time.UnixMilli(int64(msg.WakeUpTime)))
 
 	return msg
 }
 
-func (m *Machine) run(fn func(restate.RunContext) ([]byte, error)) ([]byte, error) {
+func (m *Machine) run(fn func(restate.RunContext) ([]byte, error), name string, policy options.RunRetryPolicy) ([]byte, error) {
 	entry, entryIndex := replayOrNew(
 		m,
 		func(entry *wire.RunEntryMessage) *wire.RunEntryMessage {
+			m.recordReplay("run", entry)
 			return entry
 		},
 		func() *wire.RunEntryMessage {
-			return m._run(fn)
+			return m._run(fn, name, policy)
 		},
 	)
 
@@ -312,8 +380,17 @@ type runContext struct {
 func (r runContext) Log() *slog.Logger         { return r.log }
 func (r runContext) Request() *restate.Request { return r.request }
 
-func (m *Machine) _run(fn func(restate.RunContext) ([]byte, error)) *wire.RunEntryMessage {
-	bytes, err := fn(runContext{m.ctx, m.userLog, &m.request})
+func (m *Machine) _run(fn func(restate.RunContext) ([]byte, error), name string, policy options.RunRetryPolicy) *wire.RunEntryMessage {
+	ctx, span := m.startRunSpan(name)
+	fn = m.countRunFailures(fn)
+	var bytes []byte
+	var err error
+	if policy.Enabled() {
+		bytes, err = m.runWithRetries(ctx, fn, name, policy)
+	} else {
+		bytes, err = fn(runContext{ctx, m.userLog, &m.request})
+	}
+	endSpan(span, err)
 
 	if err != nil {
 		if restate.IsTerminalError(err) {
@@ -325,13 +402,14 @@ func (m *Machine) _run(fn func(restate.RunContext) ([]byte, error)) *wire.RunEnt
 							Message: err.Error(),
 						},
 					},
+					Name: name,
 				},
 			}
 			m.Write(msg)
 
 			return msg
 		} else {
-			panic(m.newRunFailure(err))
+			panic(m.newRunFailure(name, err))
 		}
 	} else {
 		msg := &wire.RunEntryMessage{
@@ -339,6 +417,7 @@ func (m *Machine) _run(fn func(restate.RunContext) ([]byte, error)) *wire.RunEnt
 				Result: &protocol.RunEntryMessage_Value{
 					Value: bytes,
 				},
+				Name: name,
 			},
 		}
 		m.Write(msg)
@@ -347,13 +426,63 @@ func (m *Machine) _run(fn func(restate.RunContext) ([]byte, error)) *wire.RunEnt
 	}
 }
 
+// runWithRetries executes fn until it succeeds, returns a terminal error, or the policy is exhausted, in which case
+// the last error is returned as a terminal error. Attempts made by previous invocation attempts since the last stored
+// entry, as reported by the runtime from V2, count towards the policy when this is the first new entry.
+func (m *Machine) runWithRetries(ctx context.Context, fn func(restate.RunContext) ([]byte, error), name string, policy options.RunRetryPolicy) ([]byte, error) {
+	interval := policy.InitialInterval
+	if interval == 0 {
+		interval = 50 * time.Millisecond
+	}
+	factor := policy.Factor
+	if factor == 0 {
+		factor = 2
+	}
+
+	attempts := uint(0)
+	start := time.Now()
+	if m.entryIndex == uint32(len(m.entries))+1 {
+		attempts = uint(m.retryCountSinceLastStoredEntry)
+		start = start.Add(-m.durationSinceLastStoredEntry)
+	}
+
+	for {
+		bytes, err := fn(runContext{ctx, m.userLog, &m.request})
+		if err == nil || restate.IsTerminalError(err) {
+			return bytes, err
+		}
+		attempts += 1
+
+		if policy.MaxAttempts > 0 && attempts >= policy.MaxAttempts {
+			return nil, errors.NewTerminalError(fmt.Errorf("run failed after %d attempts: %w", attempts, err))
+		}
+		if policy.MaxDuration > 0 && time.Since(start)+interval > policy.MaxDuration {
+			return nil, errors.NewTerminalError(fmt.Errorf("run failed after retrying for %s: %w", time.Since(start).Round(time.Millisecond), err))
+		}
+
+		m.log.LogAttrs(m.ctx, slog.LevelWarn, "Run returned a failure, retrying", log.Error(err), slog.String("entryName", name), slog.Uint64("attempt", uint64(attempts)), slog.Duration("retryInterval", interval))
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-m.ctx.Done():
+			timer.Stop()
+			// the request is going away; leave it to Restate to retry
+			return nil, err
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * factor)
+	}
+}
+
 type runFailure struct {
 	entryIndex uint32
+	entryName  string
 	err        error
 }
 
-func (m *Machine) newRunFailure(err error) *runFailure {
-	s := &runFailure{m.entryIndex, err}
+func (m *Machine) newRunFailure(entryName string, err error) *runFailure {
+	s := &runFailure{m.entryIndex, entryName, err}
 	m.failure = s
 	return s
 }
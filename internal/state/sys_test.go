@@ -0,0 +1,76 @@
+package state_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamedEntries(t *testing.T) {
+	handler := restate.NewServiceHandler(func(ctx restate.Context, _ restate.Void) (restate.Void, error) {
+		if err := ctx.Sleep(time.Second, restate.WithName("wait")); err != nil {
+			return restate.Void{}, err
+		}
+		if err := ctx.Service("Greeter", "greet", restate.WithName("greet")).Send("bob", 0); err != nil {
+			return restate.Void{}, err
+		}
+		_ = ctx.Awakeable(restate.WithName("approval"))
+		return restate.RunAs(ctx, func(ctx restate.RunContext) (restate.Void, error) {
+			return restate.Void{}, nil
+		}, restate.WithName("charge-card"))
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	runtime := restatetest.Runtime{
+		Awakeable: func(id string) *restatetest.Completion {
+			return restatetest.Value(restatetest.JSON(t, true))
+		},
+	}
+	result := runtime.Complete(t, handler, nil)
+
+	names := make([]string, 0, len(result.Journal))
+	for _, entry := range result.Journal {
+		names = append(names, entry.(interface{ GetName() string }).GetName())
+	}
+	require.Equal(t, []string{"wait", "greet", "approval", "charge-card", ""}, names)
+}
+
+func TestRunRetryPolicy(t *testing.T) {
+	var attempts int
+	handler := restate.NewServiceHandler(func(ctx restate.Context, failures int) (int, error) {
+		attempts = 0
+		return restate.RunAs(ctx, func(ctx restate.RunContext) (int, error) {
+			attempts += 1
+			if attempts <= failures {
+				return 0, fmt.Errorf("attempt %d failed", attempts)
+			}
+			return attempts, nil
+		}, restate.WithMaxRetryAttempts(3), restate.WithInitialRetryInterval(time.Millisecond))
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	t.Run("succeeds", func(t *testing.T) {
+		var runtime restatetest.Runtime
+		result := runtime.Complete(t, handler, restatetest.JSON(t, 2))
+		require.Equal(t, restatetest.JSON(t, 3), result.Output)
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		var runtime restatetest.Runtime
+		result, err := runtime.Invoke(context.Background(), handler, restatetest.JSON(t, 3))
+		require.NoError(t, err)
+		require.True(t, result.Completed())
+		require.ErrorContains(t, result.TerminalError, "run failed after 3 attempts: attempt 3 failed")
+		require.Equal(t, 3, attempts)
+
+		run, ok := result.Journal[0].(*protocol.RunEntryMessage)
+		require.True(t, ok)
+		require.Equal(t, "run failed after 3 attempts: attempt 3 failed", run.GetFailure().GetMessage())
+	})
+}
@@ -0,0 +1,94 @@
+package state
+
+import (
+	"slices"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/internal/errors"
+	"github.com/restatedev/sdk-go/internal/futures"
+	"github.com/restatedev/sdk-go/internal/wire"
+)
+
+// after is a sleep which respects the deadline of the handler
+type after struct {
+	*futures.After
+	machine *Machine
+}
+
+func (a after) Done() error {
+	if err := a.machine.awaitDeadline(a.After); err != nil {
+		return err
+	}
+	return a.After.Done()
+}
+
+// timer is a sleep which can be cancelled, or replaced by a new sleep with Reset. Neither is journaled; as they
+// are called by the handler they happen at the same points on replay.
+type timer struct {
+	after
+	name      string
+	cancelled bool
+}
+
+func (t *timer) Done() error {
+	if t.cancelled {
+		return errors.ErrTimerCancelled
+	}
+	return t.after.Done()
+}
+
+func (t *timer) Cancel() {
+	t.cancelled = true
+}
+
+func (t *timer) Reset(d time.Duration) {
+	t.After = t.machine.after(d, t.name)
+	t.cancelled = false
+}
+
+func (m *Machine) timer(d time.Duration, name string) *timer {
+	return &timer{after: after{m.after(d, name), m}, name: name}
+}
+
+// setDeadline journals a sleep until deadline, which every later blocking operation races against. The zero time
+// removes the deadline.
+func (m *Machine) setDeadline(deadline time.Time, name string) {
+	if deadline.IsZero() {
+		m.deadline = nil
+		return
+	}
+	m.deadline = m.afterTime(func() time.Time { return deadline }, name)
+}
+
+// awaitDeadline blocks until fut or the deadline completes, returning [errors.ErrDeadlineExceeded] in the latter
+// case. If fut has already completed, nothing is journaled and its result is used even if the deadline has passed;
+// otherwise the outcome is journaled with a selector entry, so that it is the same on replay.
+func (m *Machine) awaitDeadline(fut restate.Selectable) error {
+	if m.deadline == nil || futures.EntryIndex(fut) == futures.EntryIndex(m.deadline) {
+		return nil
+	}
+
+	if next, ok := m.nextEntry(); ok {
+		// completions may have arrived in a different order than in the original attempt, so the journal tells
+		// whether fut had to race the deadline
+		entry, ok := next.(*wire.SelectorEntryMessage)
+		indexes := []uint32{futures.EntryIndex(m.deadline), futures.EntryIndex(fut)}
+		slices.Sort(indexes)
+		if !ok || !slices.Equal(entry.JournalEntries, indexes) {
+			return nil
+		}
+	} else if futures.Completed(fut) {
+		return nil
+	}
+
+	winner := m.selector(m.deadline, fut).Select()
+	if futures.EntryIndex(winner) != futures.EntryIndex(m.deadline) {
+		return nil
+	}
+	if err := futures.Failure(m.deadline); err != nil {
+		// the sleep was failed, eg because the invocation was cancelled
+		return err
+	}
+	return errors.ErrDeadlineExceeded
+}
@@ -0,0 +1,110 @@
+package state_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	_go "github.com/restatedev/sdk-go/generated/proto/go"
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimer(t *testing.T) {
+	handler := restate.NewServiceHandler(func(ctx restate.Context, _ restate.Void) (restate.Void, error) {
+		timer := ctx.Timer(time.Hour)
+		timer.Reset(time.Minute)
+		if err := timer.Done(); err != nil {
+			return restate.Void{}, err
+		}
+		timer.Cancel()
+		if err := timer.Done(); !errors.Is(err, restate.ErrTimerCancelled) {
+			return restate.Void{}, fmt.Errorf("expected a cancelled timer, got %v", err)
+		}
+		timer.Reset(time.Second)
+		return restate.Void{}, timer.Done()
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	var runtime restatetest.Runtime
+	start := time.Now()
+	result := runtime.Complete(t, handler, nil)
+	require.NoError(t, result.TerminalError)
+
+	require.Len(t, result.Journal, 4)
+	reset, ok := result.Journal[1].(*protocol.SleepEntryMessage)
+	require.True(t, ok)
+	require.Less(t, reset.WakeUpTime, uint64(start.Add(time.Hour).UnixMilli()))
+	require.IsType(t, &protocol.SleepEntryMessage{}, result.Journal[2])
+}
+
+func TestDeadline(t *testing.T) {
+	deadline := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	handler := restate.NewServiceHandler(func(ctx restate.Context, _ restate.Void) ([]string, error) {
+		early, err := restate.CallAs[string](ctx.Service("Greeter", "greet")).RequestFuture("alice")
+		if err != nil {
+			return nil, err
+		}
+		// the fake runtime completes the sleep of the deadline straight away
+		ctx.WithDeadline(deadline, restate.WithName("deadline"))
+		late, err := restate.CallAs[string](ctx.Service("Greeter", "greet")).RequestFuture("bob")
+		if err != nil {
+			return nil, err
+		}
+		// the run is acknowledged after both calls are completed, so they are awaited once they have completed
+		if _, err := restate.RunAs(ctx, func(ctx restate.RunContext) (restate.Void, error) {
+			return restate.Void{}, nil
+		}); err != nil {
+			return nil, err
+		}
+		alice, err := early.Response()
+		if err != nil {
+			return nil, err
+		}
+		bob, err := late.Response()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = restate.CallAs[string](ctx.Service("Greeter", "slow")).Request("dave")
+		if !errors.Is(err, restate.ErrDeadlineExceeded) {
+			return nil, fmt.Errorf("expected the deadline to be exceeded, got %v", err)
+		}
+
+		ctx.WithDeadline(time.Time{})
+		carol, err := restate.CallAs[string](ctx.Service("Greeter", "greet")).Request("carol")
+		if err != nil {
+			return nil, err
+		}
+		return []string{alice, bob, carol}, nil
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	runtime := restatetest.Runtime{
+		Call: func(target restatetest.Target, input []byte) *restatetest.Completion {
+			if target.Handler == "slow" {
+				return restatetest.Pending
+			}
+			return restatetest.Value(input)
+		},
+	}
+	result := runtime.Complete(t, handler, nil)
+	require.NoError(t, result.TerminalError)
+	require.Equal(t, restatetest.JSON(t, []string{"alice", "bob", "carol"}), result.Output)
+
+	sleep, ok := result.Journal[1].(*protocol.SleepEntryMessage)
+	require.True(t, ok)
+	require.Equal(t, uint64(deadline.UnixMilli()), sleep.WakeUpTime)
+	require.Equal(t, "deadline", sleep.Name)
+	// the completed calls are returned without racing the deadline, and only the slow call journals a selector
+	require.Len(t, result.Journal, 8)
+	require.IsType(t, &protocol.CallEntryMessage{}, result.Journal[4])
+	selector, ok := result.Journal[5].(*_go.SelectorEntryMessage)
+	require.True(t, ok)
+	require.Equal(t, []uint32{2, 5}, selector.JournalEntries)
+	require.EqualValues(t, 2, selector.WinningEntryIndex)
+	require.IsType(t, &protocol.CallEntryMessage{}, result.Journal[6])
+}
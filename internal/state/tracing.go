@@ -0,0 +1,161 @@
+package state
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/internal/wire"
+	"github.com/restatedev/sdk-go/tracing"
+)
+
+// Tracing is opt-in: the server starts a span for the attempt with its tracer, and puts it in the context of the
+// machine, which creates child spans from it. When there is no span in the context, nothing is recorded.
+
+func (m *Machine) span() tracing.Span {
+	return tracing.SpanFromContext(m.ctx)
+}
+
+// recordStart annotates the attempt span with the invocation and the state of its journal
+func (m *Machine) recordStart(start *wire.StartMessage) {
+	span := m.span()
+	if span == nil {
+		return
+	}
+	span.SetAttributes(
+		tracing.String("restate.invocation.id", start.DebugId),
+		tracing.Int64("restate.journal.known_entries", int64(start.KnownEntries)),
+		tracing.Int64("restate.attempt.retry_count", int64(m.retryCountSinceLastStoredEntry)),
+	)
+}
+
+// recordFailure marks the attempt span as failed, given either an error returned by the handler or a panic
+// recovered by invoke
+func (m *Machine) recordFailure(failure any) {
+	span := m.span()
+	if span == nil {
+		return
+	}
+	switch typ := failure.(type) {
+	case error:
+		span.RecordError(typ)
+	case *wire.SuspensionPanic:
+		if m.ctx.Err() == nil && stderrors.Is(typ.Err, io.EOF) {
+			span.AddEvent("suspended")
+		} else {
+			span.SetError(fmt.Sprintf("problem reading completions: %v", typ.Err))
+		}
+	case *protocolViolation:
+		span.SetError(fmt.Sprintf("Protocol violation: %v", typ.err))
+	case *entryMismatch:
+		span.SetError(EntryMismatchMessage(typ.entryIndex, typ.expectedEntry, typ.actualEntry))
+	case *concurrentContextUse:
+		span.SetError("Concurrent context use detected")
+	case *writeError:
+		span.SetError(typ.err.Error())
+	case *runFailure:
+		span.RecordError(typ.err)
+	default:
+		span.SetError(fmt.Sprint(typ))
+	}
+}
+
+func entryAttributes(entryIndex uint32, name string) []tracing.Attribute {
+	attrs := []tracing.Attribute{tracing.Int64("restate.journal.index", int64(entryIndex))}
+	if name != "" {
+		attrs = append(attrs, tracing.String("restate.journal.entry_name", name))
+	}
+	return attrs
+}
+
+// startRunSpan starts a span for the execution of a Run, which happens only when its entry is not replayed.
+// The span is nil if the attempt is not traced.
+func (m *Machine) startRunSpan(name string) (context.Context, tracing.Span) {
+	span := m.span()
+	if span == nil {
+		return m.ctx, nil
+	}
+	spanName := "run"
+	if name != "" {
+		spanName = "run " + name
+	}
+	return span.Start(m.ctx, spanName, entryAttributes(m.entryIndex, name)...)
+}
+
+func endSpan(span tracing.Span, err error) {
+	if span == nil {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End(time.Now())
+}
+
+// recordSleep emits a span covering a new sleep, from now until its wake up time
+func (m *Machine) recordSleep(name string, wakeUpTime time.Time) {
+	span := m.span()
+	if span == nil {
+		return
+	}
+	spanName := "sleep"
+	if name != "" {
+		spanName = "sleep " + name
+	}
+	_, child := span.Start(m.ctx, spanName, entryAttributes(m.entryIndex, name)...)
+	child.End(wakeUpTime)
+}
+
+// recordReplay marks a replayed entry on the attempt span, instead of emitting its span again
+func (m *Machine) recordReplay(kind string, entry wire.Message) {
+	if span := m.span(); span != nil {
+		span.AddEvent("replayed "+kind, entryAttributes(m.entryIndex, wire.EntryName(entry))...)
+	}
+}
+
+// injectTraceContext adds the trace context of the attempt to the headers of an outgoing call
+func (m *Machine) injectTraceContext(headers []*protocol.Header) []*protocol.Header {
+	span := m.span()
+	if span == nil {
+		return headers
+	}
+	traceparent, tracestate := span.TraceContext()
+	if traceparent == "" {
+		return headers
+	}
+
+	for i, value := range []string{traceparent, tracestate} {
+		if key := tracing.TraceContextHeaders[i]; value != "" && !hasHeader(headers, key) {
+			headers = append(headers, &protocol.Header{Key: key, Value: value})
+		}
+	}
+	return headers
+}
+
+// withoutTraceContext strips the propagated trace context from replayed call headers, as it differs between attempts
+func withoutTraceContext(headers []*protocol.Header, expected []*protocol.Header) []*protocol.Header {
+	filtered := make([]*protocol.Header, 0, len(headers))
+outer:
+	for _, header := range headers {
+		for _, key := range tracing.TraceContextHeaders {
+			if strings.EqualFold(header.Key, key) && !hasHeader(expected, key) {
+				continue outer
+			}
+		}
+		filtered = append(filtered, header)
+	}
+	return filtered
+}
+
+func hasHeader(headers []*protocol.Header, key string) bool {
+	for _, header := range headers {
+		if strings.EqualFold(header.Key, key) {
+			return true
+		}
+	}
+	return false
+}
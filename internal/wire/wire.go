@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"reflect"
 	"sync"
 	"sync/atomic"
 
@@ -41,19 +42,26 @@ const (
 	OutputEntryMessageType Type = 0x0400 + 1
 
 	// State
-	GetStateEntryMessageType      Type = 0x0800
-	SetStateEntryMessageType      Type = 0x0800 + 1
-	ClearStateEntryMessageType    Type = 0x0800 + 2
-	ClearAllStateEntryMessageType Type = 0x0800 + 3
-	GetStateKeysEntryMessageType  Type = 0x0800 + 4
+	GetStateEntryMessageType        Type = 0x0800
+	SetStateEntryMessageType        Type = 0x0800 + 1
+	ClearStateEntryMessageType      Type = 0x0800 + 2
+	ClearAllStateEntryMessageType   Type = 0x0800 + 3
+	GetStateKeysEntryMessageType    Type = 0x0800 + 4
+	GetPromiseEntryMessageType      Type = 0x0800 + 8
+	PeekPromiseEntryMessageType     Type = 0x0800 + 9
+	CompletePromiseEntryMessageType Type = 0x0800 + 10
 
 	//SysCalls
-	SleepEntryMessageType             Type = 0x0C00
-	CallEntryMessageType              Type = 0x0C00 + 1
-	OneWayCallEntryMessageType        Type = 0x0C00 + 2
-	AwakeableEntryMessageType         Type = 0x0C00 + 3
-	CompleteAwakeableEntryMessageType Type = 0x0C00 + 4
-	RunEntryMessageType               Type = 0x0C00 + 5
+	SleepEntryMessageType               Type = 0x0C00
+	CallEntryMessageType                Type = 0x0C00 + 1
+	OneWayCallEntryMessageType          Type = 0x0C00 + 2
+	AwakeableEntryMessageType           Type = 0x0C00 + 3
+	CompleteAwakeableEntryMessageType   Type = 0x0C00 + 4
+	RunEntryMessageType                 Type = 0x0C00 + 5
+	CancelInvocationEntryMessageType    Type = 0x0C00 + 6
+	GetCallInvocationIdEntryMessageType Type = 0x0C00 + 7
+	AttachInvocationEntryMessageType    Type = 0x0C00 + 8
+	GetInvocationOutputEntryMessageType Type = 0x0C00 + 9
 
 	// Custom
 	SelectorEntryMessageType Type = 0xFC03
@@ -100,6 +108,15 @@ type Message interface {
 	proto.Message
 }
 
+// EntryName returns the name of a journal entry, or the empty string if it is unnamed or not an entry
+func EntryName(message Message) string {
+	named, ok := message.(interface{ GetName() string })
+	if !ok || reflect.ValueOf(message).IsNil() {
+		return ""
+	}
+	return named.GetName()
+}
+
 func MessageType(message Message) Type {
 	switch message.(type) {
 	case *StartMessage:
@@ -126,6 +143,12 @@ func MessageType(message Message) Type {
 		return ClearAllStateEntryMessageType
 	case *GetStateKeysEntryMessage:
 		return GetStateKeysEntryMessageType
+	case *GetPromiseEntryMessage:
+		return GetPromiseEntryMessageType
+	case *PeekPromiseEntryMessage:
+		return PeekPromiseEntryMessageType
+	case *CompletePromiseEntryMessage:
+		return CompletePromiseEntryMessageType
 	case *SleepEntryMessage:
 		return SleepEntryMessageType
 	case *CallEntryMessage:
@@ -138,6 +161,14 @@ func MessageType(message Message) Type {
 		return CompleteAwakeableEntryMessageType
 	case *RunEntryMessage:
 		return RunEntryMessageType
+	case *CancelInvocationEntryMessage:
+		return CancelInvocationEntryMessageType
+	case *GetCallInvocationIdEntryMessage:
+		return GetCallInvocationIdEntryMessageType
+	case *AttachInvocationEntryMessage:
+		return AttachInvocationEntryMessageType
+	case *GetInvocationOutputEntryMessage:
+		return GetInvocationOutputEntryMessageType
 	case *SelectorEntryMessage:
 		return SelectorEntryMessageType
 	}
@@ -266,6 +297,27 @@ var (
 
 			return msg, proto.Unmarshal(bytes, msg)
 		},
+		SuspensionMessageType: func(header Header, bytes []byte) (Message, error) {
+			msg := &SuspensionMessage{
+				Header: header,
+			}
+
+			return msg, proto.Unmarshal(bytes, msg)
+		},
+		ErrorMessageType: func(header Header, bytes []byte) (Message, error) {
+			msg := &ErrorMessage{
+				Header: header,
+			}
+
+			return msg, proto.Unmarshal(bytes, msg)
+		},
+		EndMessageType: func(header Header, bytes []byte) (Message, error) {
+			msg := &EndMessage{
+				Header: header,
+			}
+
+			return msg, proto.Unmarshal(bytes, msg)
+		},
 		InputEntryMessageType: func(header Header, bytes []byte) (Message, error) {
 			msg := &InputEntryMessage{
 				Header: header,
@@ -315,6 +367,33 @@ var (
 
 			return msg, proto.Unmarshal(bytes, msg)
 		},
+		GetPromiseEntryMessageType: func(header Header, bytes []byte) (Message, error) {
+			msg := &GetPromiseEntryMessage{}
+
+			if header.Flag.Completed() {
+				msg.completable.complete()
+			}
+
+			return msg, proto.Unmarshal(bytes, msg)
+		},
+		PeekPromiseEntryMessageType: func(header Header, bytes []byte) (Message, error) {
+			msg := &PeekPromiseEntryMessage{}
+
+			if header.Flag.Completed() {
+				msg.completable.complete()
+			}
+
+			return msg, proto.Unmarshal(bytes, msg)
+		},
+		CompletePromiseEntryMessageType: func(header Header, bytes []byte) (Message, error) {
+			msg := &CompletePromiseEntryMessage{}
+
+			if header.Flag.Completed() {
+				msg.completable.complete()
+			}
+
+			return msg, proto.Unmarshal(bytes, msg)
+		},
 		CompletionMessageType: func(header Header, bytes []byte) (Message, error) {
 			msg := &CompletionMessage{
 				Header: header,
@@ -371,6 +450,40 @@ var (
 
 			return msg, proto.Unmarshal(bytes, msg)
 		},
+		CancelInvocationEntryMessageType: func(header Header, bytes []byte) (Message, error) {
+			msg := &CancelInvocationEntryMessage{
+				Header: header,
+			}
+
+			return msg, proto.Unmarshal(bytes, msg)
+		},
+		GetCallInvocationIdEntryMessageType: func(header Header, bytes []byte) (Message, error) {
+			msg := &GetCallInvocationIdEntryMessage{}
+
+			if header.Flag.Completed() {
+				msg.completable.complete()
+			}
+
+			return msg, proto.Unmarshal(bytes, msg)
+		},
+		AttachInvocationEntryMessageType: func(header Header, bytes []byte) (Message, error) {
+			msg := &AttachInvocationEntryMessage{}
+
+			if header.Flag.Completed() {
+				msg.completable.complete()
+			}
+
+			return msg, proto.Unmarshal(bytes, msg)
+		},
+		GetInvocationOutputEntryMessageType: func(header Header, bytes []byte) (Message, error) {
+			msg := &GetInvocationOutputEntryMessage{}
+
+			if header.Flag.Completed() {
+				msg.completable.complete()
+			}
+
+			return msg, proto.Unmarshal(bytes, msg)
+		},
 		SelectorEntryMessageType: func(header Header, bytes []byte) (Message, error) {
 			msg := &SelectorEntryMessage{}
 
@@ -480,6 +593,69 @@ func (a *GetStateKeysEntryMessage) Complete(c *protocol.CompletionMessage) error
 	return nil
 }
 
+type GetPromiseEntryMessage struct {
+	completable
+	protocol.GetPromiseEntryMessage
+}
+
+var _ CompleteableMessage = (*GetPromiseEntryMessage)(nil)
+
+func (a *GetPromiseEntryMessage) Complete(c *protocol.CompletionMessage) error {
+	switch result := c.Result.(type) {
+	case *protocol.CompletionMessage_Value:
+		a.Result = &protocol.GetPromiseEntryMessage_Value{Value: result.Value}
+	case *protocol.CompletionMessage_Failure:
+		a.Result = &protocol.GetPromiseEntryMessage_Failure{Failure: result.Failure}
+	case *protocol.CompletionMessage_Empty:
+		return fmt.Errorf("received empty completion for getpromise")
+	}
+
+	a.complete()
+	return nil
+}
+
+type PeekPromiseEntryMessage struct {
+	completable
+	protocol.PeekPromiseEntryMessage
+}
+
+var _ CompleteableMessage = (*PeekPromiseEntryMessage)(nil)
+
+func (a *PeekPromiseEntryMessage) Complete(c *protocol.CompletionMessage) error {
+	switch result := c.Result.(type) {
+	case *protocol.CompletionMessage_Value:
+		a.Result = &protocol.PeekPromiseEntryMessage_Value{Value: result.Value}
+	case *protocol.CompletionMessage_Failure:
+		a.Result = &protocol.PeekPromiseEntryMessage_Failure{Failure: result.Failure}
+	case *protocol.CompletionMessage_Empty:
+		a.Result = &protocol.PeekPromiseEntryMessage_Empty{Empty: result.Empty}
+	}
+
+	a.complete()
+	return nil
+}
+
+type CompletePromiseEntryMessage struct {
+	completable
+	protocol.CompletePromiseEntryMessage
+}
+
+var _ CompleteableMessage = (*CompletePromiseEntryMessage)(nil)
+
+func (a *CompletePromiseEntryMessage) Complete(c *protocol.CompletionMessage) error {
+	switch result := c.Result.(type) {
+	case *protocol.CompletionMessage_Empty:
+		a.Result = &protocol.CompletePromiseEntryMessage_Empty{Empty: result.Empty}
+	case *protocol.CompletionMessage_Failure:
+		a.Result = &protocol.CompletePromiseEntryMessage_Failure{Failure: result.Failure}
+	case *protocol.CompletionMessage_Value:
+		return fmt.Errorf("received value completion for completepromise")
+	}
+
+	a.complete()
+	return nil
+}
+
 type CompletionMessage struct {
 	Header
 	protocol.CompletionMessage
@@ -565,6 +741,74 @@ type RunEntryMessage struct {
 
 var _ AckableMessage = (*RunEntryMessage)(nil)
 
+type CancelInvocationEntryMessage struct {
+	Header
+	protocol.CancelInvocationEntryMessage
+}
+
+type GetCallInvocationIdEntryMessage struct {
+	completable
+	protocol.GetCallInvocationIdEntryMessage
+}
+
+var _ CompleteableMessage = (*GetCallInvocationIdEntryMessage)(nil)
+
+func (a *GetCallInvocationIdEntryMessage) Complete(c *protocol.CompletionMessage) error {
+	switch result := c.Result.(type) {
+	case *protocol.CompletionMessage_Value:
+		a.Result = &protocol.GetCallInvocationIdEntryMessage_Value{Value: string(result.Value)}
+	case *protocol.CompletionMessage_Failure:
+		a.Result = &protocol.GetCallInvocationIdEntryMessage_Failure{Failure: result.Failure}
+	case *protocol.CompletionMessage_Empty:
+		return fmt.Errorf("received empty completion for get call invocation id")
+	}
+
+	a.complete()
+	return nil
+}
+
+type AttachInvocationEntryMessage struct {
+	completable
+	protocol.AttachInvocationEntryMessage
+}
+
+var _ CompleteableMessage = (*AttachInvocationEntryMessage)(nil)
+
+func (a *AttachInvocationEntryMessage) Complete(c *protocol.CompletionMessage) error {
+	switch result := c.Result.(type) {
+	case *protocol.CompletionMessage_Value:
+		a.Result = &protocol.AttachInvocationEntryMessage_Value{Value: result.Value}
+	case *protocol.CompletionMessage_Failure:
+		a.Result = &protocol.AttachInvocationEntryMessage_Failure{Failure: result.Failure}
+	case *protocol.CompletionMessage_Empty:
+		return fmt.Errorf("received empty completion for attach invocation")
+	}
+
+	a.complete()
+	return nil
+}
+
+type GetInvocationOutputEntryMessage struct {
+	completable
+	protocol.GetInvocationOutputEntryMessage
+}
+
+var _ CompleteableMessage = (*GetInvocationOutputEntryMessage)(nil)
+
+func (a *GetInvocationOutputEntryMessage) Complete(c *protocol.CompletionMessage) error {
+	switch result := c.Result.(type) {
+	case *protocol.CompletionMessage_Empty:
+		a.Result = &protocol.GetInvocationOutputEntryMessage_Empty{Empty: result.Empty}
+	case *protocol.CompletionMessage_Value:
+		a.Result = &protocol.GetInvocationOutputEntryMessage_Value{Value: result.Value}
+	case *protocol.CompletionMessage_Failure:
+		a.Result = &protocol.GetInvocationOutputEntryMessage_Failure{Failure: result.Failure}
+	}
+
+	a.complete()
+	return nil
+}
+
 type SelectorEntryMessage struct {
 	ackable
 	_go.SelectorEntryMessage
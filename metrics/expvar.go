@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"expvar"
+	"strconv"
+)
+
+// Expvar is a [Recorder] which publishes counters with the expvar package, and so are served on /debug/vars
+// alongside the other published variables. Counters are keyed by service and handler, as "Service/handler".
+type Expvar struct {
+	attempts        *expvar.Map
+	errors          *expvar.Map
+	durationSeconds *expvar.Map
+	replayedEntries *expvar.Map
+	newEntries      *expvar.Map
+	suspensions     *expvar.Map
+	runFailures     *expvar.Map
+}
+
+var _ Recorder = &Expvar{}
+
+// NewExpvar creates a new Expvar recorder, publishing a map with the provided name. Like [expvar.Publish], it
+// panics if the name is already in use.
+func NewExpvar(name string) *Expvar {
+	e := &Expvar{
+		attempts:        new(expvar.Map),
+		errors:          new(expvar.Map),
+		durationSeconds: new(expvar.Map),
+		replayedEntries: new(expvar.Map),
+		newEntries:      new(expvar.Map),
+		suspensions:     new(expvar.Map),
+		runFailures:     new(expvar.Map),
+	}
+
+	root := expvar.NewMap(name)
+	root.Set("attempts", e.attempts)
+	root.Set("errors", e.errors)
+	root.Set("attempt_duration_seconds", e.durationSeconds)
+	root.Set("replayed_entries", e.replayedEntries)
+	root.Set("new_entries", e.newEntries)
+	root.Set("suspensions", e.suspensions)
+	root.Set("run_failures", e.runFailures)
+
+	return e
+}
+
+func (e *Expvar) RecordAttempt(attempt Attempt) {
+	key := attempt.Service + "/" + attempt.Handler
+
+	e.attempts.Add(key+"."+string(attempt.Outcome), 1)
+	if attempt.ErrorCode != 0 {
+		e.errors.Add(key+"."+strconv.FormatUint(uint64(attempt.ErrorCode), 10), 1)
+	}
+	e.durationSeconds.AddFloat(key, attempt.Duration.Seconds())
+	e.replayedEntries.Add(key, int64(attempt.ReplayedEntries))
+	e.newEntries.Add(key, int64(attempt.NewEntries))
+	e.runFailures.Add(key, int64(attempt.RunFailures))
+	if attempt.Outcome == Suspended {
+		e.suspensions.Add(key, 1)
+	}
+}
@@ -0,0 +1,63 @@
+// Package metrics provides hooks to observe the invocations handled by a [github.com/restatedev/sdk-go/server.Restate]
+// server, along with recorders that expose them in the Prometheus text format or via expvar.
+package metrics
+
+import (
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+)
+
+// Outcome describes how an attempt of an invocation ended
+type Outcome string
+
+const (
+	// Success means that the handler returned an output
+	Success Outcome = "success"
+	// TerminalFailure means that the handler returned a terminal error, completing the invocation
+	TerminalFailure Outcome = "terminal_failure"
+	// RetryableFailure means that the attempt failed with an error that Restate will retry, eg a failed Run or a panic
+	RetryableFailure Outcome = "retryable_failure"
+	// Suspended means that the invocation suspended while waiting on a completion
+	Suspended Outcome = "suspended"
+	// Cancelled means that the incoming request was cancelled before the attempt ended
+	Cancelled Outcome = "cancelled"
+	// JournalMismatch means that the handler did not deterministically replay its journal (570)
+	JournalMismatch Outcome = "journal_mismatch"
+	// ProtocolViolation means that the service protocol was violated, eg by concurrent use of the context (571)
+	ProtocolViolation Outcome = "protocol_violation"
+)
+
+// Attempt summarises a single attempt of an invocation
+type Attempt struct {
+	Service string
+	Handler string
+	Outcome Outcome
+	// ErrorCode is the code of the failure sent to Restate, if the outcome is a failure
+	ErrorCode restate.Code
+	// Duration is the time taken to process the attempt, including time spent suspended on completions
+	Duration time.Duration
+	// ReplayedEntries is the number of journal entries sent by Restate at the start of the attempt, excluding the input
+	ReplayedEntries int
+	// NewEntries is the number of journal entries written by this attempt
+	NewEntries int
+	// RunFailures is the number of executions of Run blocks that returned an error during this attempt,
+	// including those retried in-process
+	RunFailures int
+}
+
+// Recorder receives a summary of every attempt once it has ended. Implementations must be safe for concurrent use.
+type Recorder interface {
+	RecordAttempt(attempt Attempt)
+}
+
+// Recorders fans out each attempt to all of the provided recorders
+type Recorders []Recorder
+
+var _ Recorder = Recorders(nil)
+
+func (r Recorders) RecordAttempt(attempt Attempt) {
+	for _, recorder := range r {
+		recorder.RecordAttempt(attempt)
+	}
+}
@@ -0,0 +1,76 @@
+package metrics_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/restatedev/sdk-go/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+var attempts = []metrics.Attempt{
+	{Service: "Greeter", Handler: "greet", Outcome: metrics.Suspended, Duration: 20 * time.Millisecond, NewEntries: 2, RunFailures: 1},
+	{Service: "Greeter", Handler: "greet", Outcome: metrics.Success, Duration: 2 * time.Second, ReplayedEntries: 2, NewEntries: 1},
+	{Service: "Greeter", Handler: "greet", Outcome: metrics.JournalMismatch, ErrorCode: 570, Duration: time.Millisecond, ReplayedEntries: 3},
+}
+
+func TestPrometheus(t *testing.T) {
+	recorder := metrics.NewPrometheus(0.01, 1)
+	metrics.Recorders{recorder}.RecordAttempt(attempts[0])
+	for _, attempt := range attempts[1:] {
+		recorder.RecordAttempt(attempt)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, recorder.Write(&buf))
+	require.Equal(t, `# HELP restate_invocation_attempts_total Number of invocation attempts by outcome
+# TYPE restate_invocation_attempts_total counter
+restate_invocation_attempts_total{service="Greeter",handler="greet",outcome="journal_mismatch"} 1
+restate_invocation_attempts_total{service="Greeter",handler="greet",outcome="success"} 1
+restate_invocation_attempts_total{service="Greeter",handler="greet",outcome="suspended"} 1
+# HELP restate_invocation_attempt_duration_seconds Duration of invocation attempts
+# TYPE restate_invocation_attempt_duration_seconds histogram
+restate_invocation_attempt_duration_seconds_bucket{service="Greeter",handler="greet",le="0.01"} 1
+restate_invocation_attempt_duration_seconds_bucket{service="Greeter",handler="greet",le="1"} 2
+restate_invocation_attempt_duration_seconds_bucket{service="Greeter",handler="greet",le="+Inf"} 3
+restate_invocation_attempt_duration_seconds_sum{service="Greeter",handler="greet"} 2.021
+restate_invocation_attempt_duration_seconds_count{service="Greeter",handler="greet"} 3
+# HELP restate_journal_entries_total Number of journal entries replayed from or written to Restate
+# TYPE restate_journal_entries_total counter
+restate_journal_entries_total{service="Greeter",handler="greet",kind="replayed"} 5
+restate_journal_entries_total{service="Greeter",handler="greet",kind="new"} 3
+# HELP restate_suspensions_total Number of invocation attempts that suspended
+# TYPE restate_suspensions_total counter
+restate_suspensions_total{service="Greeter",handler="greet"} 1
+# HELP restate_run_failures_total Number of failed executions of Run blocks
+# TYPE restate_run_failures_total counter
+restate_run_failures_total{service="Greeter",handler="greet"} 1
+# HELP restate_errors_total Number of invocation attempts that failed, by error code
+# TYPE restate_errors_total counter
+restate_errors_total{service="Greeter",handler="greet",code="570"} 1
+`, buf.String())
+}
+
+func TestExpvar(t *testing.T) {
+	recorder := metrics.NewExpvar("restate_test")
+	for _, attempt := range attempts {
+		recorder.RecordAttempt(attempt)
+	}
+
+	var published map[string]map[string]float64
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get("restate_test").String()), &published))
+	require.Equal(t, map[string]float64{
+		"Greeter/greet.journal_mismatch": 1,
+		"Greeter/greet.success":          1,
+		"Greeter/greet.suspended":        1,
+	}, published["attempts"])
+	require.Equal(t, map[string]float64{"Greeter/greet.570": 1}, published["errors"])
+	require.Equal(t, map[string]float64{"Greeter/greet": 5}, published["replayed_entries"])
+	require.Equal(t, map[string]float64{"Greeter/greet": 3}, published["new_entries"])
+	require.Equal(t, map[string]float64{"Greeter/greet": 1}, published["suspensions"])
+	require.Equal(t, map[string]float64{"Greeter/greet": 1}, published["run_failures"])
+	require.InDelta(t, 2.021, published["attempt_duration_seconds"]["Greeter/greet"], 1e-9)
+}
@@ -0,0 +1,209 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the attempt duration histogram buckets
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type handlerKey struct {
+	service string
+	handler string
+}
+
+type outcomeKey struct {
+	handlerKey
+	outcome Outcome
+}
+
+type codeKey struct {
+	handlerKey
+	code uint32
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+type handlerCounters struct {
+	duration        histogram
+	replayedEntries uint64
+	newEntries      uint64
+	suspensions     uint64
+	runFailures     uint64
+}
+
+// Prometheus is a [Recorder] which aggregates attempts in memory and serves them over HTTP in the Prometheus text
+// exposition format, eg by registering it on a mux under /metrics.
+type Prometheus struct {
+	buckets []float64
+
+	mutex    sync.Mutex
+	attempts map[outcomeKey]uint64
+	errors   map[codeKey]uint64
+	handlers map[handlerKey]*handlerCounters
+}
+
+var _ Recorder = &Prometheus{}
+var _ http.Handler = &Prometheus{}
+
+// NewPrometheus creates a new Prometheus recorder. The duration histogram uses buckets if provided, otherwise
+// [DefaultBuckets].
+func NewPrometheus(buckets ...float64) *Prometheus {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &Prometheus{
+		buckets:  buckets,
+		attempts: make(map[outcomeKey]uint64),
+		errors:   make(map[codeKey]uint64),
+		handlers: make(map[handlerKey]*handlerCounters),
+	}
+}
+
+func (p *Prometheus) RecordAttempt(attempt Attempt) {
+	key := handlerKey{attempt.Service, attempt.Handler}
+	seconds := attempt.Duration.Seconds()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.attempts[outcomeKey{key, attempt.Outcome}] += 1
+	if attempt.ErrorCode != 0 {
+		p.errors[codeKey{key, uint32(attempt.ErrorCode)}] += 1
+	}
+
+	counters, ok := p.handlers[key]
+	if !ok {
+		counters = &handlerCounters{duration: histogram{counts: make([]uint64, len(p.buckets))}}
+		p.handlers[key] = counters
+	}
+	for i, bound := range p.buckets {
+		if seconds <= bound {
+			counters.duration.counts[i] += 1
+		}
+	}
+	counters.duration.count += 1
+	counters.duration.sum += seconds
+	counters.replayedEntries += uint64(attempt.ReplayedEntries)
+	counters.newEntries += uint64(attempt.NewEntries)
+	counters.runFailures += uint64(attempt.RunFailures)
+	if attempt.Outcome == Suspended {
+		counters.suspensions += 1
+	}
+}
+
+// ServeHTTP writes all metrics in the Prometheus text exposition format
+func (p *Prometheus) ServeHTTP(writer http.ResponseWriter, _ *http.Request) {
+	writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = p.Write(writer)
+}
+
+// Write writes all metrics in the Prometheus text exposition format to w
+func (p *Prometheus) Write(w io.Writer) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	b := bufio.NewWriter(w)
+
+	handlers := make([]handlerKey, 0, len(p.handlers))
+	for key := range p.handlers {
+		handlers = append(handlers, key)
+	}
+	sort.Slice(handlers, func(i, j int) bool { return handlers[i].less(handlers[j]) })
+
+	attempts := make([]outcomeKey, 0, len(p.attempts))
+	for key := range p.attempts {
+		attempts = append(attempts, key)
+	}
+	sort.Slice(attempts, func(i, j int) bool {
+		if attempts[i].handlerKey != attempts[j].handlerKey {
+			return attempts[i].handlerKey.less(attempts[j].handlerKey)
+		}
+		return attempts[i].outcome < attempts[j].outcome
+	})
+
+	errors := make([]codeKey, 0, len(p.errors))
+	for key := range p.errors {
+		errors = append(errors, key)
+	}
+	sort.Slice(errors, func(i, j int) bool {
+		if errors[i].handlerKey != errors[j].handlerKey {
+			return errors[i].handlerKey.less(errors[j].handlerKey)
+		}
+		return errors[i].code < errors[j].code
+	})
+
+	writeHeader(b, "restate_invocation_attempts_total", "counter", "Number of invocation attempts by outcome")
+	for _, key := range attempts {
+		fmt.Fprintf(b, "restate_invocation_attempts_total{%s,outcome=%s} %d\n", key.labels(), quote(string(key.outcome)), p.attempts[key])
+	}
+
+	writeHeader(b, "restate_invocation_attempt_duration_seconds", "histogram", "Duration of invocation attempts")
+	for _, key := range handlers {
+		duration := p.handlers[key].duration
+		for i, bound := range p.buckets {
+			fmt.Fprintf(b, "restate_invocation_attempt_duration_seconds_bucket{%s,le=%s} %d\n", key.labels(), quote(strconv.FormatFloat(bound, 'g', -1, 64)), duration.counts[i])
+		}
+		fmt.Fprintf(b, "restate_invocation_attempt_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", key.labels(), duration.count)
+		fmt.Fprintf(b, "restate_invocation_attempt_duration_seconds_sum{%s} %s\n", key.labels(), strconv.FormatFloat(duration.sum, 'g', -1, 64))
+		fmt.Fprintf(b, "restate_invocation_attempt_duration_seconds_count{%s} %d\n", key.labels(), duration.count)
+	}
+
+	writeHeader(b, "restate_journal_entries_total", "counter", "Number of journal entries replayed from or written to Restate")
+	for _, key := range handlers {
+		fmt.Fprintf(b, "restate_journal_entries_total{%s,kind=\"replayed\"} %d\n", key.labels(), p.handlers[key].replayedEntries)
+		fmt.Fprintf(b, "restate_journal_entries_total{%s,kind=\"new\"} %d\n", key.labels(), p.handlers[key].newEntries)
+	}
+
+	writeHeader(b, "restate_suspensions_total", "counter", "Number of invocation attempts that suspended")
+	for _, key := range handlers {
+		fmt.Fprintf(b, "restate_suspensions_total{%s} %d\n", key.labels(), p.handlers[key].suspensions)
+	}
+
+	writeHeader(b, "restate_run_failures_total", "counter", "Number of failed executions of Run blocks")
+	for _, key := range handlers {
+		fmt.Fprintf(b, "restate_run_failures_total{%s} %d\n", key.labels(), p.handlers[key].runFailures)
+	}
+
+	writeHeader(b, "restate_errors_total", "counter", "Number of invocation attempts that failed, by error code")
+	for _, key := range errors {
+		fmt.Fprintf(b, "restate_errors_total{%s,code=\"%d\"} %d\n", key.labels(), key.code, p.errors[key])
+	}
+
+	return b.Flush()
+}
+
+func (k handlerKey) less(other handlerKey) bool {
+	if k.service != other.service {
+		return k.service < other.service
+	}
+	return k.handler < other.handler
+}
+
+func (k handlerKey) labels() string {
+	return "service=" + quote(k.service) + ",handler=" + quote(k.handler)
+}
+
+func writeHeader(w io.Writer, name, typ, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func quote(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
@@ -0,0 +1,76 @@
+package restate
+
+import (
+	"github.com/restatedev/sdk-go/internal"
+	"github.com/restatedev/sdk-go/internal/options"
+)
+
+// HandlerFunc is the untyped form of a handler, operating on the raw input and output payloads.
+type HandlerFunc func(ctx Context, input []byte) (output []byte, err error)
+
+// Middleware wraps a handler to implement cross-cutting concerns, such as checking claims in
+// Request().Headers, scoping to a tenant or audit logging. A middleware may inspect or replace the input
+// before calling next, and inspect or replace the output and error that next returns.
+//
+// Middleware can be provided to a server with .WithMiddleware(), and to services, objects, workflows and individual
+// handlers with [WithMiddleware]. Server middleware runs first, then service middleware, then handler middleware.
+//
+// The ctx passed to next must be the Context that the middleware received, or a value which embeds it, so that
+// it still implements the context interface of the handler type (eg [ObjectContext]).
+//
+// Middleware runs on every attempt of an invocation, including attempts that replay the journal, and so it must
+// be deterministic in the same way as handler code: calls to the Context are journaled as usual, and
+// non-deterministic side effects should be performed with Run. Logs written with ctx.Log() are dropped during
+// replay, like those of the handler. Middleware must not recover panics raised by Context methods, which are
+// used by the SDK to suspend the invocation or to fail it on non-determinism.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Chain applies middleware to handler, such that the first middleware is the outermost
+func Chain(handler HandlerFunc, middleware ...Middleware) HandlerFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
+// chainOptions applies middleware stored in options, where it cannot be typed as []Middleware
+func chainOptions(handler HandlerFunc, middleware options.Middlewares) HandlerFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i].(Middleware)(handler)
+	}
+	return handler
+}
+
+// serviceMiddlewareHandler applies the middleware of the service, object or workflow that a handler is registered
+// on, outside of the middleware of the handler. The handler is wrapped rather than modified, as it may be
+// registered more than once.
+type serviceMiddlewareHandler struct {
+	ServiceHandler
+	middleware options.Middlewares
+}
+
+func (h serviceMiddlewareHandler) Call(ctx Context, bytes []byte) ([]byte, error) {
+	return chainOptions(h.ServiceHandler.Call, h.middleware)(ctx, bytes)
+}
+
+type objectMiddlewareHandler struct {
+	ObjectHandler
+	middleware options.Middlewares
+}
+
+func (h objectMiddlewareHandler) Call(ctx ObjectContext, bytes []byte) ([]byte, error) {
+	return chainOptions(func(ctx Context, bytes []byte) ([]byte, error) {
+		return h.ObjectHandler.Call(internal.ContextAs[ObjectContext](ctx), bytes)
+	}, h.middleware)(ctx, bytes)
+}
+
+type workflowMiddlewareHandler struct {
+	WorkflowHandler
+	middleware options.Middlewares
+}
+
+func (h workflowMiddlewareHandler) Call(ctx WorkflowContext, bytes []byte) ([]byte, error) {
+	return chainOptions(func(ctx Context, bytes []byte) ([]byte, error) {
+		return h.WorkflowHandler.Call(internal.ContextAs[WorkflowContext](ctx), bytes)
+	}, h.middleware)(ctx, bytes)
+}
@@ -0,0 +1,85 @@
+package restate_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	var order []string
+	trace := func(name string) restate.Middleware {
+		return func(next restate.HandlerFunc) restate.HandlerFunc {
+			return func(ctx restate.Context, input []byte) ([]byte, error) {
+				order = append(order, name)
+				return next(ctx, input)
+			}
+		}
+	}
+	requireTenant := func(next restate.HandlerFunc) restate.HandlerFunc {
+		return func(ctx restate.Context, input []byte) ([]byte, error) {
+			if ctx.Request().Headers["x-tenant"] == "" {
+				return nil, restate.TerminalError(fmt.Errorf("missing tenant"), 401)
+			}
+			return next(ctx, input)
+		}
+	}
+	uppercase := func(next restate.HandlerFunc) restate.HandlerFunc {
+		return func(ctx restate.Context, input []byte) ([]byte, error) {
+			output, err := next(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+			return bytes.ToUpper(output), nil
+		}
+	}
+
+	greet := restate.NewObjectHandler(func(ctx restate.ObjectContext, name string) (string, error) {
+		order = append(order, "handler")
+		return "hello " + name, ctx.Set("greeted", name)
+	}, restate.WithMiddleware(trace("handler middleware"), uppercase))
+	// registering the handler again doesn't stack the middleware of the objects
+	restate.NewObject("OtherGreeter", restate.WithMiddleware(trace("other service middleware"))).Handler("greet", greet)
+	handler := restate.NewObject("Greeter", restate.WithMiddleware(trace("service middleware"))).Handler("greet", greet).Handlers()["greet"]
+
+	runtime := restatetest.Runtime{
+		Key:        "bob",
+		Middleware: []restate.Middleware{trace("server middleware"), requireTenant},
+	}
+
+	result, err := runtime.Invoke(context.Background(), handler, restatetest.JSON(t, "bob"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"server middleware"}, order)
+	require.EqualValues(t, 401, restate.ErrorCode(result.TerminalError))
+	require.ErrorContains(t, result.TerminalError, "missing tenant")
+
+	order = nil
+	runtime.Headers = map[string]string{"x-tenant": "acme"}
+	result, err = runtime.Invoke(context.Background(), handler, restatetest.JSON(t, "bob"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"server middleware", "service middleware", "handler middleware", "handler"}, order)
+	require.Equal(t, restatetest.JSON(t, "HELLO BOB"), result.Output)
+	require.Equal(t, restatetest.JSON(t, "bob"), result.State["greeted"])
+
+	// middleware runs again on replay
+	require.NoError(t, runtime.CheckDeterminism(context.Background(), handler, restatetest.JSON(t, "bob")))
+
+	// middleware applies to handlers inferred from methods
+	order = nil
+	reflected := restate.Object(&greeter{}, restate.WithMiddleware(trace("service middleware"), uppercase)).Handlers()["Greet"]
+	result, err = runtime.Invoke(context.Background(), reflected, restatetest.JSON(t, "bob"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"server middleware", "service middleware"}, order)
+	require.Equal(t, restatetest.JSON(t, "HELLO BOB"), result.Output)
+}
+
+type greeter struct{}
+
+func (greeter) Greet(ctx restate.ObjectContext, name string) (string, error) {
+	return "hello " + name, nil
+}
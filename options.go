@@ -1,6 +1,8 @@
 package restate
 
 import (
+	"time"
+
 	"github.com/restatedev/sdk-go/encoding"
 	"github.com/restatedev/sdk-go/internal/options"
 )
@@ -11,19 +13,31 @@ type withCodec struct {
 
 var _ options.GetOption = withCodec{}
 var _ options.SetOption = withCodec{}
+var _ options.StateKeyOption = withCodec{}
 var _ options.RunOption = withCodec{}
 var _ options.AwakeableOption = withCodec{}
 var _ options.ResolveAwakeableOption = withCodec{}
+var _ options.PromiseOption = withCodec{}
 var _ options.CallOption = withCodec{}
+var _ options.AttachOption = withCodec{}
+var _ options.IngressRequestOption = withCodec{}
+var _ options.IngressSendOption = withCodec{}
 
 func (w withCodec) BeforeGet(opts *options.GetOptions)             { opts.Codec = w.codec }
 func (w withCodec) BeforeSet(opts *options.SetOptions)             { opts.Codec = w.codec }
+func (w withCodec) BeforeStateKey(opts *options.StateKeyOptions)   { opts.Codec = w.codec }
 func (w withCodec) BeforeRun(opts *options.RunOptions)             { opts.Codec = w.codec }
 func (w withCodec) BeforeAwakeable(opts *options.AwakeableOptions) { opts.Codec = w.codec }
 func (w withCodec) BeforeResolveAwakeable(opts *options.ResolveAwakeableOptions) {
 	opts.Codec = w.codec
 }
-func (w withCodec) BeforeCall(opts *options.CallOptions) { opts.Codec = w.codec }
+func (w withCodec) BeforePromise(opts *options.PromiseOptions) { opts.Codec = w.codec }
+func (w withCodec) BeforeCall(opts *options.CallOptions)       { opts.Codec = w.codec }
+func (w withCodec) BeforeAttach(opts *options.AttachOptions)   { opts.Codec = w.codec }
+func (w withCodec) BeforeIngressRequest(opts *options.IngressRequestOptions) {
+	opts.Codec = w.codec
+}
+func (w withCodec) BeforeIngressSend(opts *options.IngressSendOptions) { opts.Codec = w.codec }
 
 // WithCodec is an option that can be provided to many different functions that perform (de)serialisation
 // in order to specify a custom codec with which to (de)serialise instead of the default of JSON.
@@ -42,6 +56,8 @@ var _ options.ServiceHandlerOption = withPayloadCodec{}
 var _ options.ServiceOption = withPayloadCodec{}
 var _ options.ObjectHandlerOption = withPayloadCodec{}
 var _ options.ObjectOption = withPayloadCodec{}
+var _ options.WorkflowHandlerOption = withPayloadCodec{}
+var _ options.WorkflowOption = withPayloadCodec{}
 
 func (w withPayloadCodec) BeforeServiceHandler(opts *options.ServiceHandlerOptions) {
 	opts.Codec = w.codec
@@ -49,12 +65,18 @@ func (w withPayloadCodec) BeforeServiceHandler(opts *options.ServiceHandlerOptio
 func (w withPayloadCodec) BeforeObjectHandler(opts *options.ObjectHandlerOptions) {
 	opts.Codec = w.codec
 }
+func (w withPayloadCodec) BeforeWorkflowHandler(opts *options.WorkflowHandlerOptions) {
+	opts.Codec = w.codec
+}
 func (w withPayloadCodec) BeforeService(opts *options.ServiceOptions) {
 	opts.DefaultCodec = w.codec
 }
 func (w withPayloadCodec) BeforeObject(opts *options.ObjectOptions) {
 	opts.DefaultCodec = w.codec
 }
+func (w withPayloadCodec) BeforeWorkflow(opts *options.WorkflowOptions) {
+	opts.DefaultCodec = w.codec
+}
 
 // WithPayloadCodec is an option that can be provided to handler/service options
 // in order to specify a custom [encoding.PayloadCodec] with which to (de)serialise and
@@ -79,12 +101,190 @@ type withHeaders struct {
 }
 
 var _ options.CallOption = withHeaders{}
+var _ options.IngressClientOption = withHeaders{}
+var _ options.IngressRequestOption = withHeaders{}
+var _ options.IngressSendOption = withHeaders{}
 
 func (w withHeaders) BeforeCall(opts *options.CallOptions) {
 	opts.Headers = w.headers
 }
+func (w withHeaders) BeforeIngressClient(opts *options.IngressClientOptions) {
+	opts.Headers = w.headers
+}
+func (w withHeaders) BeforeIngressRequest(opts *options.IngressRequestOptions) {
+	opts.Headers = w.headers
+}
+func (w withHeaders) BeforeIngressSend(opts *options.IngressSendOptions) {
+	opts.Headers = w.headers
+}
 
-// WithHeaders is an option to specify outgoing headers when making a call
+// WithHeaders is an option to specify outgoing headers when making a call, either from a handler
+// or through the ingress
 func WithHeaders(headers map[string]string) withHeaders {
 	return withHeaders{headers}
 }
+
+type withName struct {
+	name string
+}
+
+var _ options.RunOption = withName{}
+var _ options.SleepOption = withName{}
+var _ options.CallOption = withName{}
+var _ options.AwakeableOption = withName{}
+var _ options.AttachOption = withName{}
+
+func (w withName) BeforeRun(opts *options.RunOptions)             { opts.Name = w.name }
+func (w withName) BeforeSleep(opts *options.SleepOptions)         { opts.Name = w.name }
+func (w withName) BeforeCall(opts *options.CallOptions)           { opts.Name = w.name }
+func (w withName) BeforeAwakeable(opts *options.AwakeableOptions) { opts.Name = w.name }
+func (w withName) BeforeAttach(opts *options.AttachOptions)       { opts.Name = w.name }
+
+// WithName is an option to name the journal entry of a Run, Sleep, call, awakeable or attach, eg "charge-card".
+// The name is shown in the journal and included in logs and error messages about the entry, but it is not
+// checked on replay, so steps can be renamed without breaking in-flight invocations.
+func WithName(name string) withName {
+	return withName{name}
+}
+
+type withIdempotencyKey struct {
+	key string
+}
+
+var _ options.CallOption = withIdempotencyKey{}
+
+func (w withIdempotencyKey) BeforeCall(opts *options.CallOptions) { opts.IdempotencyKey = w.key }
+
+// WithIdempotencyKey is an option to specify the idempotency key of a call or send. Restate will only start one
+// invocation for each idempotency key of a handler, and later calls with the same key receive the result of that
+// invocation, so that a request which is made again, for example after the handler code has changed, is deduplicated.
+// It requires service protocol V3 to be negotiated with Restate.
+func WithIdempotencyKey(key string) withIdempotencyKey {
+	return withIdempotencyKey{key}
+}
+
+type withMaxRetryAttempts struct {
+	attempts uint
+}
+
+var _ options.RunOption = withMaxRetryAttempts{}
+
+func (w withMaxRetryAttempts) BeforeRun(opts *options.RunOptions) {
+	opts.RetryPolicy.MaxAttempts = w.attempts
+}
+
+// WithMaxRetryAttempts is an option to retry a Run in-process when it returns a non-terminal error,
+// making at most the provided number of attempts, including the first. Once the attempts are exhausted,
+// the last error is journaled as a terminal error and returned by Run, so that the handler can compensate.
+func WithMaxRetryAttempts(attempts uint) withMaxRetryAttempts {
+	return withMaxRetryAttempts{attempts}
+}
+
+type withInitialRetryInterval struct {
+	interval time.Duration
+}
+
+var _ options.RunOption = withInitialRetryInterval{}
+
+func (w withInitialRetryInterval) BeforeRun(opts *options.RunOptions) {
+	opts.RetryPolicy.InitialInterval = w.interval
+}
+
+// WithInitialRetryInterval is an option to retry a Run in-process when it returns a non-terminal error,
+// waiting the provided interval before the first retry. Defaults to 50ms.
+func WithInitialRetryInterval(interval time.Duration) withInitialRetryInterval {
+	return withInitialRetryInterval{interval}
+}
+
+type withRetryFactor struct {
+	factor float64
+}
+
+var _ options.RunOption = withRetryFactor{}
+
+func (w withRetryFactor) BeforeRun(opts *options.RunOptions) {
+	opts.RetryPolicy.Factor = w.factor
+}
+
+// WithRetryFactor is an option to retry a Run in-process when it returns a non-terminal error,
+// multiplying the interval between retries by the provided factor after each retry. Defaults to 2.
+func WithRetryFactor(factor float64) withRetryFactor {
+	return withRetryFactor{factor}
+}
+
+type withMaxRetryDuration struct {
+	duration time.Duration
+}
+
+var _ options.RunOption = withMaxRetryDuration{}
+
+func (w withMaxRetryDuration) BeforeRun(opts *options.RunOptions) {
+	opts.RetryPolicy.MaxDuration = w.duration
+}
+
+// WithMaxRetryDuration is an option to retry a Run in-process when it returns a non-terminal error,
+// for at most the provided duration since the first attempt. Once it has elapsed, the last error is
+// journaled as a terminal error and returned by Run, so that the handler can compensate.
+func WithMaxRetryDuration(duration time.Duration) withMaxRetryDuration {
+	return withMaxRetryDuration{duration}
+}
+
+type withMiddleware struct {
+	middleware options.Middlewares
+}
+
+var _ options.ServiceHandlerOption = withMiddleware{}
+var _ options.ObjectHandlerOption = withMiddleware{}
+var _ options.WorkflowHandlerOption = withMiddleware{}
+var _ options.ServiceOption = withMiddleware{}
+var _ options.ObjectOption = withMiddleware{}
+var _ options.WorkflowOption = withMiddleware{}
+
+func (w withMiddleware) BeforeServiceHandler(opts *options.ServiceHandlerOptions) {
+	opts.Middleware = append(opts.Middleware, w.middleware...)
+}
+func (w withMiddleware) BeforeObjectHandler(opts *options.ObjectHandlerOptions) {
+	opts.Middleware = append(opts.Middleware, w.middleware...)
+}
+func (w withMiddleware) BeforeWorkflowHandler(opts *options.WorkflowHandlerOptions) {
+	opts.Middleware = append(opts.Middleware, w.middleware...)
+}
+func (w withMiddleware) BeforeService(opts *options.ServiceOptions) {
+	opts.Middleware = append(opts.Middleware, w.middleware...)
+}
+func (w withMiddleware) BeforeObject(opts *options.ObjectOptions) {
+	opts.Middleware = append(opts.Middleware, w.middleware...)
+}
+func (w withMiddleware) BeforeWorkflow(opts *options.WorkflowOptions) {
+	opts.Middleware = append(opts.Middleware, w.middleware...)
+}
+
+// WithMiddleware is an option to wrap handlers with [Middleware]. It can be provided to NewService, NewObject
+// and NewWorkflow to apply to all of their handlers, or to the constructor of an individual handler.
+// The first middleware provided is the outermost.
+func WithMiddleware(middleware ...Middleware) withMiddleware {
+	w := withMiddleware{make(options.Middlewares, 0, len(middleware))}
+	for _, m := range middleware {
+		w.middleware = append(w.middleware, m)
+	}
+	return w
+}
+
+type withLazyState struct {
+	lazy bool
+}
+
+var _ options.ObjectOption = withLazyState{}
+var _ options.WorkflowOption = withLazyState{}
+
+func (w withLazyState) BeforeObject(opts *options.ObjectOptions)     { opts.LazyState = &w.lazy }
+func (w withLazyState) BeforeWorkflow(opts *options.WorkflowOptions) { opts.LazyState = &w.lazy }
+
+// WithLazyState is an option for NewObject and NewWorkflow to control whether Restate sends the state with each
+// invocation (the default) or only on demand when it is read, overriding the default of the server. Lazy state
+// avoids sending large state with every invocation, at the cost of a round trip for each key that is read, which
+// can be amortised with Prefetch. Lazy state is advertised to Restate with version 2 of the service discovery
+// protocol; when Restate negotiates version 1, the option is left out of discovery and state is sent eagerly.
+func WithLazyState(lazy bool) withLazyState {
+	return withLazyState{lazy}
+}
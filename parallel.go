@@ -0,0 +1,139 @@
+package restate
+
+import (
+	stderrors "errors"
+	"fmt"
+	"sort"
+)
+
+// ParallelError is returned by [ParallelFor] and [Map] when some of the operations failed. It matches each of their
+// errors with errors.Is and errors.As, in the order of their positions, so for example [ErrorCode] returns the code
+// of the error at the lowest position.
+type ParallelError struct {
+	// Errors holds the error of each operation that failed, by its position
+	Errors map[int]error
+}
+
+func (e *ParallelError) positions() []int {
+	positions := make([]int, 0, len(e.Errors))
+	for i := range e.Errors {
+		positions = append(positions, i)
+	}
+	sort.Ints(positions)
+	return positions
+}
+
+func (e *ParallelError) Error() string {
+	positions := e.positions()
+	if len(positions) == 1 {
+		return fmt.Sprintf("operation %d failed: %v", positions[0], e.Errors[positions[0]])
+	}
+	return fmt.Sprintf("%d operations failed, the first being operation %d: %v", len(positions), positions[0], e.Errors[positions[0]])
+}
+
+func (e *ParallelError) Unwrap() []error {
+	positions := e.positions()
+	errs := make([]error, len(positions))
+	for i, position := range positions {
+		errs[i] = e.Errors[position]
+	}
+	return errs
+}
+
+// ParallelFor starts n operations, with at most limit of them in flight at once, and blocks until they have all
+// completed. start is called with the position of each operation, in order, and returns its future, which it should
+// keep in order to read the result once ParallelFor returns. If start returns neither a future nor an error, the
+// operation fails with a terminal error. A limit of 0 or less starts all of them at once.
+// Each time an operation completes, the next one is started; which one completed first is journaled like
+// [Context.Select], so that the same operations are started at the same points on replay. Failed operations don't
+// stop the others, and are returned together as a [*ParallelError].
+func ParallelFor(ctx Context, n, limit int, start func(i int) (Selectable, error)) error {
+	var pending []Selectable
+	var pendingPositions []int
+	errs := map[int]error{}
+
+	next := 0
+	for next < n || len(pending) > 0 {
+		for next < n && (limit <= 0 || len(pending) < limit) {
+			fut, err := start(next)
+			if err == nil && fut == nil {
+				err = TerminalError(fmt.Errorf("operation %d was started without a future", next))
+			}
+			if err != nil {
+				errs[next] = err
+			} else {
+				pending = append(pending, fut)
+				pendingPositions = append(pendingPositions, next)
+			}
+			next++
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		index, err := SelectIndexed(ctx, pending...).Select()
+		if err != nil {
+			errs[pendingPositions[index]] = err
+		}
+		pending = append(pending[:index], pending[index+1:]...)
+		pendingPositions = append(pendingPositions[:index], pendingPositions[index+1:]...)
+	}
+
+	if len(errs) > 0 {
+		return &ParallelError{errs}
+	}
+	return nil
+}
+
+// Map calls request for each of inputs, with at most limit requests in flight at once, and returns their responses
+// in the order of inputs. See [ParallelFor] for how the requests are scheduled. If some of them fail, the responses
+// of the others are still returned, along with a [*ParallelError].
+//
+//	outputs, err := restate.Map(ctx, items, 20, func(item Item) (restate.TypedResponseFuture[Result], error) {
+//		return restate.ClientAs[Item, Result](ctx.Service("Processor", "process")).RequestFuture(item)
+//	})
+func Map[I any, O any](ctx Context, inputs []I, limit int, request func(input I) (TypedResponseFuture[O], error)) ([]O, error) {
+	futs := make([]TypedResponseFuture[O], len(inputs))
+	err := ParallelFor(ctx, len(inputs), limit, func(i int) (Selectable, error) {
+		fut, err := request(inputs[i])
+		if err != nil {
+			return nil, err
+		}
+		futs[i] = fut
+		return fut, nil
+	})
+
+	var failed map[int]error
+	var perr *ParallelError
+	if stderrors.As(err, &perr) {
+		failed = perr.Errors
+	}
+	outputs := make([]O, len(inputs))
+	for i, fut := range futs {
+		if _, ok := failed[i]; ok || fut == nil {
+			continue
+		}
+		output, rerr := completedResponse(fut)
+		if rerr != nil {
+			return outputs, rerr
+		}
+		outputs[i] = output
+	}
+	return outputs, err
+}
+
+// completedResponseFuture is implemented by the futures of calls made with a Context, to read the response of a call
+// which is known to have completed without racing it against the deadline of the handler again
+type completedResponseFuture interface {
+	CompletedResponse(output any) error
+}
+
+func completedResponse[O any](fut TypedResponseFuture[O]) (output O, err error) {
+	if typed, ok := fut.(typedResponseFuture[O]); ok {
+		if completed, ok := typed.ResponseFuture.(completedResponseFuture); ok {
+			err = completed.CompletedResponse(&output)
+			return
+		}
+	}
+	return fut.Response()
+}
@@ -0,0 +1,92 @@
+package restate_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMap(t *testing.T) {
+	newHandler := func(deadline time.Time) restate.Handler {
+		handler := restate.NewServiceHandler(func(ctx restate.Context, inputs []int) ([]int, error) {
+			ctx.WithDeadline(deadline)
+			outputs, err := restate.Map(ctx, inputs, 3, func(input int) (restate.TypedResponseFuture[int], error) {
+				return restate.ClientAs[int, int](ctx.Service("Doubler", "double")).RequestFuture(input)
+			})
+			var perr *restate.ParallelError
+			if !errors.As(err, &perr) || len(perr.Errors) != 1 || perr.Errors[4] == nil {
+				return nil, fmt.Errorf("expected the fifth call to fail, got %v", err)
+			}
+			return outputs, nil
+		})
+		restate.NewService("Test").Handler("handle", handler)
+		return handler
+	}
+
+	runtime := restatetest.Runtime{
+		Call: func(target restatetest.Target, input []byte) *restatetest.Completion {
+			var n int
+			require.NoError(t, json.Unmarshal(input, &n))
+			if n == 5 {
+				return restatetest.Failure(restate.TerminalError(fmt.Errorf("odd one out"), 400))
+			}
+			return restatetest.Value(restatetest.JSON(t, n*2))
+		},
+	}
+	inputs := restatetest.JSON(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	outputs := restatetest.JSON(t, []int{2, 4, 6, 8, 0, 12, 14, 16, 18, 20})
+
+	t.Run("limit", func(t *testing.T) {
+		result := runtime.Complete(t, newHandler(time.Time{}), inputs)
+		require.NoError(t, result.TerminalError)
+		require.Equal(t, outputs, result.Output)
+
+		// each completion is journaled by a selector entry, and never more than 3 calls are in flight
+		inFlight, maxInFlight, calls := 0, 0, 0
+		for _, entry := range result.Journal {
+			switch entry.ProtoReflect().Descriptor().Name() {
+			case "CallEntryMessage":
+				calls++
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+			case "SelectorEntryMessage":
+				inFlight--
+			}
+		}
+		require.Equal(t, 10, calls)
+		require.Equal(t, 3, maxInFlight)
+		require.Zero(t, inFlight)
+	})
+
+	t.Run("deadline passed", func(t *testing.T) {
+		// the fake runtime completes the sleep of the deadline straight away, but the responses of the calls that
+		// completed are still returned
+		result := runtime.Complete(t, newHandler(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)), inputs)
+		require.NoError(t, result.TerminalError)
+		require.Equal(t, outputs, result.Output)
+	})
+}
+
+func TestParallelForWithoutFuture(t *testing.T) {
+	handler := restate.NewServiceHandler(func(ctx restate.Context, _ restate.Void) (string, error) {
+		err := restate.ParallelFor(ctx, 2, 0, func(i int) (restate.Selectable, error) {
+			if i == 1 {
+				return nil, nil
+			}
+			return ctx.After(time.Second), nil
+		})
+		return err.Error(), nil
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	var runtime restatetest.Runtime
+	result := runtime.Complete(t, handler, nil)
+	require.NoError(t, result.TerminalError)
+	require.Equal(t, restatetest.JSON(t, "operation 1 failed: operation 1 was started without a future"), result.Output)
+}
@@ -0,0 +1,107 @@
+// protoc-gen-go-restate is a protoc plugin which generates typed Restate clients for the services in
+// proto files, for use both within handlers and through the Restate ingress.
+//
+// Services are Restate Services unless annotated with the options in proto/dev/restate/sdk/go.proto:
+//
+//	service Counter {
+//	  option (dev.restate.sdk.go.service_type) = VIRTUAL_OBJECT;
+//	  rpc Add (AddRequest) returns (AddResponse) {}
+//	}
+//
+// The generated clients address each service by its name without the proto package, and each handler by its rpc name,
+// and they (de)serialise messages with [restate.WithProto].
+//
+// It is typically invoked with buf, alongside protoc-gen-go:
+//
+//	plugins:
+//	  - plugin: go
+//	    out: .
+//	    opt: paths=source_relative
+//	  - plugin: go-restate
+//	    out: .
+//	    opt: paths=source_relative
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+
+	"github.com/restatedev/sdk-go/generated/proto/dev/restate/sdk"
+	"github.com/restatedev/sdk-go/internal/codegen"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func main() {
+	protogen.Options{
+		ParamFunc: flag.CommandLine.Set,
+	}.Run(generate)
+}
+
+func generate(gen *protogen.Plugin) error {
+	gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+	for _, f := range gen.Files {
+		if !f.Generate || len(f.Services) == 0 {
+			continue
+		}
+		if err := generateFile(gen, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func generateFile(gen *protogen.Plugin, file *protogen.File) error {
+	g := gen.NewGeneratedFile(file.GeneratedFilenamePrefix+"_restate.pb.go", file.GoImportPath)
+	g.P("// Code generated by protoc-gen-go-restate. DO NOT EDIT.")
+	g.P("// source: ", file.Desc.Path())
+	g.P()
+	g.P("package ", file.GoPackageName)
+
+	qualify := func(importPath, name string) string {
+		return g.QualifiedGoIdent(protogen.GoIdent{GoName: name, GoImportPath: protogen.GoImportPath(importPath)})
+	}
+
+	services := make([]codegen.Service, 0, len(file.Services))
+	for _, service := range file.Services {
+		s := codegen.Service{
+			GoName:  service.GoName,
+			Name:    string(service.Desc.Name()),
+			Type:    serviceType(service),
+			Options: []string{qualify(codegen.SDKImportPath, "WithProto")},
+		}
+		for _, method := range service.Methods {
+			if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+				return fmt.Errorf("%s.%s: streaming rpcs are not supported by Restate", service.Desc.FullName(), method.Desc.Name())
+			}
+			s.Handlers = append(s.Handlers, codegen.Handler{
+				GoName: method.GoName,
+				Name:   string(method.Desc.Name()),
+				Input:  "*" + g.QualifiedGoIdent(method.Input.GoIdent),
+				Output: "*" + g.QualifiedGoIdent(method.Output.GoIdent),
+			})
+		}
+		services = append(services, s)
+	}
+
+	var buf bytes.Buffer
+	if err := codegen.Write(&buf, qualify, services); err != nil {
+		return err
+	}
+	g.P(buf.String())
+
+	return nil
+}
+
+func serviceType(service *protogen.Service) codegen.ServiceType {
+	switch proto.GetExtension(service.Desc.Options(), sdk.E_ServiceType).(sdk.ServiceType) {
+	case sdk.ServiceType_VIRTUAL_OBJECT:
+		return codegen.ServiceTypeObject
+	case sdk.ServiceType_WORKFLOW:
+		return codegen.ServiceTypeWorkflow
+	default:
+		return codegen.ServiceTypeService
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// request reads the CodeGeneratorRequest in testdata/name.textproto, adding the files it imports from the registry
+// before it, as protoc would
+func request(t *testing.T, name string) *pluginpb.CodeGeneratorRequest {
+	text, err := os.ReadFile(filepath.Join("testdata", name+".textproto"))
+	require.NoError(t, err)
+	req := &pluginpb.CodeGeneratorRequest{}
+	require.NoError(t, prototext.Unmarshal(text, req))
+
+	var files []*descriptorpb.FileDescriptorProto
+	seen := map[string]bool{}
+	var add func(path string)
+	add = func(path string) {
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		file, err := protoregistry.GlobalFiles.FindFileByPath(path)
+		require.NoError(t, err)
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).Path())
+		}
+		files = append(files, protodesc.ToFileDescriptorProto(file))
+	}
+	for _, file := range req.ProtoFile {
+		for _, dependency := range file.Dependency {
+			add(dependency)
+		}
+	}
+	req.ProtoFile = append(files, req.ProtoFile...)
+	return req
+}
+
+func TestGenerate(t *testing.T) {
+	gen, err := protogen.Options{}.New(request(t, "greeter"))
+	require.NoError(t, err)
+	require.NoError(t, generate(gen))
+
+	response := gen.Response()
+	require.Empty(t, response.GetError())
+	require.Len(t, response.File, 1)
+	require.Equal(t, "greeter_restate.pb.go", response.File[0].GetName())
+
+	golden := filepath.Join("testdata", "greeter_restate.pb.go.golden")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, []byte(response.File[0].GetContent()), 0o644))
+	}
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), response.File[0].GetContent(), "run go test ./protoc-gen-go-restate -update to update the golden file")
+}
+
+func TestGenerateStreaming(t *testing.T) {
+	req := request(t, "greeter")
+	req.ProtoFile[len(req.ProtoFile)-1].Service[0].Method[0].ClientStreaming = proto.Bool(true)
+
+	gen, err := protogen.Options{}.New(req)
+	require.NoError(t, err)
+	require.EqualError(t, generate(gen), "greeter.Greeter.Greet: streaming rpcs are not supported by Restate")
+}
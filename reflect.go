@@ -15,11 +15,13 @@ type serviceNamer interface {
 }
 
 var (
-	typeOfContext             = reflect.TypeOf((*Context)(nil)).Elem()
-	typeOfObjectContext       = reflect.TypeOf((*ObjectContext)(nil)).Elem()
-	typeOfSharedObjectContext = reflect.TypeOf((*ObjectSharedContext)(nil)).Elem()
-	typeOfVoid                = reflect.TypeOf((*Void)(nil)).Elem()
-	typeOfError               = reflect.TypeOf((*error)(nil)).Elem()
+	typeOfContext               = reflect.TypeOf((*Context)(nil)).Elem()
+	typeOfObjectContext         = reflect.TypeOf((*ObjectContext)(nil)).Elem()
+	typeOfSharedObjectContext   = reflect.TypeOf((*ObjectSharedContext)(nil)).Elem()
+	typeOfWorkflowContext       = reflect.TypeOf((*WorkflowContext)(nil)).Elem()
+	typeOfSharedWorkflowContext = reflect.TypeOf((*WorkflowSharedContext)(nil)).Elem()
+	typeOfVoid                  = reflect.TypeOf((*Void)(nil)).Elem()
+	typeOfError                 = reflect.TypeOf((*error)(nil)).Elem()
 )
 
 // Object converts a struct with methods into a Virtual Object where each correctly-typed
@@ -161,6 +163,80 @@ func Service(service any, opts ...options.ServiceOption) *service {
 	return definition
 }
 
+// Workflow converts a struct with methods into a Restate Workflow where each correctly-typed
+// and exported method of the struct will become a handler on the Workflow. The Workflow name defaults
+// to the name of the struct, but this can be overidden by providing a `ServiceName() string` method.
+// The handler name is the name of the method. Handler methods should be of the type `WorkflowHandlerFn[I, O]`
+// or `WorkflowSharedHandlerFn[I, O]`, and there should be exactly one method of the former type.
+//
+// Input types will be deserialised with the provided codec (defaults to JSON) except when they are restate.Void,
+// in which case no input bytes or content type may be sent.
+// Output types will be serialised with the provided codec (defaults to JSON) except when they are restate.Void,
+// in which case no data will be sent and no content type set.
+func Workflow(workflow any, opts ...options.WorkflowOption) *workflow {
+	typ := reflect.TypeOf(workflow)
+	val := reflect.ValueOf(workflow)
+	var name string
+	if sn, ok := workflow.(serviceNamer); ok {
+		name = sn.ServiceName()
+	} else {
+		name = reflect.Indirect(val).Type().Name()
+	}
+	definition := NewWorkflow(name, opts...)
+
+	for m := 0; m < typ.NumMethod(); m++ {
+		method := typ.Method(m)
+		mtype := method.Type
+		mname := method.Name
+		// Method must be exported.
+		if !method.IsExported() {
+			continue
+		}
+		// Method needs three ins: receiver, WorkflowContext, I
+		if mtype.NumIn() != 3 {
+			continue
+		}
+
+		var handlerType internal.ServiceHandlerType
+
+		switch mtype.In(1) {
+		case typeOfWorkflowContext:
+			handlerType = internal.ServiceHandlerType_WORKFLOW
+		case typeOfSharedWorkflowContext:
+			handlerType = internal.ServiceHandlerType_SHARED
+		default:
+			// first parameter is not a workflow context
+			continue
+		}
+
+		// Method needs two outs: O, and error
+		if mtype.NumOut() != 2 {
+			continue
+		}
+
+		// The second return type of the method must be error.
+		if returnType := mtype.Out(1); returnType != typeOfError {
+			continue
+		}
+
+		input := mtype.In(2)
+		output := mtype.Out(0)
+
+		definition.Handler(mname, &workflowReflectHandler{
+			options.WorkflowHandlerOptions{},
+			handlerType,
+			reflectHandler{
+				fn:       method.Func,
+				receiver: val,
+				input:    input,
+				output:   output,
+			},
+		})
+	}
+
+	return definition
+}
+
 type reflectHandler struct {
 	fn       reflect.Value
 	receiver reflect.Value
@@ -179,6 +255,15 @@ type objectReflectHandler struct {
 var _ ObjectHandler = (*objectReflectHandler)(nil)
 
 func (h *objectReflectHandler) Call(ctx ObjectContext, bytes []byte) ([]byte, error) {
+	if len(h.options.Middleware) == 0 {
+		return h.call(ctx, bytes)
+	}
+	return chainOptions(func(ctx Context, bytes []byte) ([]byte, error) {
+		return h.call(internal.ContextAs[ObjectContext](ctx), bytes)
+	}, h.options.Middleware)(ctx, bytes)
+}
+
+func (h *objectReflectHandler) call(ctx ObjectContext, bytes []byte) ([]byte, error) {
 	input := reflect.New(h.input)
 
 	if err := encoding.Unmarshal(h.options.Codec, bytes, input.Interface()); err != nil {
@@ -222,6 +307,67 @@ func (h *objectReflectHandler) HandlerType() *internal.ServiceHandlerType {
 	return &h.handlerType
 }
 
+type workflowReflectHandler struct {
+	options     options.WorkflowHandlerOptions
+	handlerType internal.ServiceHandlerType
+	reflectHandler
+}
+
+var _ WorkflowHandler = (*workflowReflectHandler)(nil)
+
+func (h *workflowReflectHandler) Call(ctx WorkflowContext, bytes []byte) ([]byte, error) {
+	if len(h.options.Middleware) == 0 {
+		return h.call(ctx, bytes)
+	}
+	return chainOptions(func(ctx Context, bytes []byte) ([]byte, error) {
+		return h.call(internal.ContextAs[WorkflowContext](ctx), bytes)
+	}, h.options.Middleware)(ctx, bytes)
+}
+
+func (h *workflowReflectHandler) call(ctx WorkflowContext, bytes []byte) ([]byte, error) {
+	input := reflect.New(h.input)
+
+	if err := encoding.Unmarshal(h.options.Codec, bytes, input.Interface()); err != nil {
+		return nil, TerminalError(fmt.Errorf("request could not be decoded into handler input type: %w", err), http.StatusBadRequest)
+	}
+
+	// we are sure about the fn signature so it's safe to do this
+	output := h.fn.Call([]reflect.Value{
+		h.receiver,
+		reflect.ValueOf(ctx),
+		input.Elem(),
+	})
+
+	outI := output[0].Interface()
+	errI := output[1].Interface()
+	if errI != nil {
+		return nil, errI.(error)
+	}
+
+	bytes, err := encoding.Marshal(h.options.Codec, outI)
+	if err != nil {
+		return nil, TerminalError(fmt.Errorf("failed to serialize output: %w", err))
+	}
+
+	return bytes, nil
+}
+
+func (h *workflowReflectHandler) getOptions() *options.WorkflowHandlerOptions {
+	return &h.options
+}
+
+func (h *workflowReflectHandler) InputPayload() *encoding.InputPayload {
+	return encoding.InputPayloadFor(h.options.Codec, reflect.Zero(h.input).Interface())
+}
+
+func (h *workflowReflectHandler) OutputPayload() *encoding.OutputPayload {
+	return encoding.OutputPayloadFor(h.options.Codec, reflect.Zero(h.output).Interface())
+}
+
+func (h *workflowReflectHandler) HandlerType() *internal.ServiceHandlerType {
+	return &h.handlerType
+}
+
 type serviceReflectHandler struct {
 	options options.ServiceHandlerOptions
 	reflectHandler
@@ -230,6 +376,13 @@ type serviceReflectHandler struct {
 var _ ServiceHandler = (*serviceReflectHandler)(nil)
 
 func (h *serviceReflectHandler) Call(ctx Context, bytes []byte) ([]byte, error) {
+	if len(h.options.Middleware) == 0 {
+		return h.call(ctx, bytes)
+	}
+	return chainOptions(h.call, h.options.Middleware)(ctx, bytes)
+}
+
+func (h *serviceReflectHandler) call(ctx Context, bytes []byte) ([]byte, error) {
 	input := reflect.New(h.input)
 
 	if err := encoding.Unmarshal(h.options.Codec, bytes, input.Interface()); err != nil {
@@ -0,0 +1,284 @@
+// restate-gen generates typed Restate clients for structs whose methods are handlers, as accepted by
+// [restate.Service], [restate.Object] and [restate.Workflow]. The clients can be used both within handlers
+// and through the Restate ingress, so that renaming a handler or changing its types breaks compilation
+// of its callers.
+//
+// It is intended to be run with go:generate from the package that defines the structs:
+//
+//	//go:generate go run github.com/restatedev/sdk-go/restate-gen -type checkout,userSession
+//
+// The kind of each service is inferred from the context type accepted by its handlers, and its name is the
+// name of the struct unless it has a ServiceName method returning a constant, mirroring the reflection
+// performed by [restate.Service].
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/restatedev/sdk-go/internal/codegen"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of struct type names; must be set")
+	output    = flag.String("output", "restate_clients.go", "output file name, relative to the package directory")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of restate-gen:\n")
+	fmt.Fprintf(os.Stderr, "\trestate-gen -type T[,T...] [-output file] [directory]\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+
+	if err := run(dir, strings.Split(*typeNames, ","), *output); err != nil {
+		fmt.Fprintf(os.Stderr, "restate-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir string, typeNames []string, output string) error {
+	outputPath := filepath.Join(dir, output)
+
+	pkg, files, info, err := loadPackage(dir, outputPath)
+	if err != nil {
+		return err
+	}
+
+	imports := newImports(pkg)
+
+	services := make([]codegen.Service, 0, len(typeNames))
+	for _, typeName := range typeNames {
+		service, err := inspect(pkg, files, info, imports, strings.TrimSpace(typeName))
+		if err != nil {
+			return err
+		}
+		services = append(services, service)
+	}
+
+	var body bytes.Buffer
+	if err := codegen.Write(&body, imports.qualify, services); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by restate-gen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg.Name())
+	for _, path := range imports.order {
+		fmt.Fprintf(&buf, "\t%s %q\n", imports.names[path], path)
+	}
+	fmt.Fprintf(&buf, ")\n%s", body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated code: %w", err)
+	}
+
+	return os.WriteFile(outputPath, src, 0o644)
+}
+
+// loadPackage type checks the package in dir, excluding tests and any previously generated output.
+// Type errors are ignored, as callers may refer to clients which are yet to be (re)generated.
+func loadPackage(dir, outputPath string) (*types.Package, []*ast.File, *types.Info, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") && info.Name() != filepath.Base(outputPath)
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, nil, nil, fmt.Errorf("expected exactly one package in %s, found %d", dir, len(pkgs))
+	}
+
+	var files []*ast.File
+	for _, p := range pkgs {
+		for _, f := range p.Files {
+			files = append(files, f)
+		}
+	}
+
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(error) {},
+	}
+	info := &types.Info{Types: map[ast.Expr]types.TypeAndValue{}}
+	pkg, _ := conf.Check(files[0].Name.Name, fset, files, info)
+
+	return pkg, files, info, nil
+}
+
+func inspect(pkg *types.Package, files []*ast.File, info *types.Info, imports *imports, typeName string) (codegen.Service, error) {
+	obj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return codegen.Service{}, fmt.Errorf("type %s not found in package %s", typeName, pkg.Name())
+	}
+
+	name := typeName
+	if serviceName, ok, err := serviceNameOf(files, info, typeName); err != nil {
+		return codegen.Service{}, err
+	} else if ok {
+		name = serviceName
+	}
+
+	service := codegen.Service{
+		GoName: codegen.GoName(name),
+		Name:   name,
+	}
+
+	var serviceType *codegen.ServiceType
+	methods := types.NewMethodSet(types.NewPointer(obj.Type()))
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.At(i).Obj().(*types.Func)
+		if !method.Exported() {
+			continue
+		}
+		signature := method.Type().(*types.Signature)
+		handlerType, ok := handlerServiceType(signature)
+		if !ok {
+			continue
+		}
+		if serviceType != nil && *serviceType != handlerType {
+			return codegen.Service{}, fmt.Errorf("%s: handler %s accepts a context for a different kind of service than the other handlers", typeName, method.Name())
+		}
+		serviceType = &handlerType
+
+		service.Handlers = append(service.Handlers, codegen.Handler{
+			GoName: method.Name(),
+			Name:   method.Name(),
+			Input:  types.TypeString(signature.Params().At(1).Type(), imports.qualifier),
+			Output: types.TypeString(signature.Results().At(0).Type(), imports.qualifier),
+		})
+	}
+
+	if serviceType == nil {
+		return codegen.Service{}, fmt.Errorf("%s has no handler methods", typeName)
+	}
+	service.Type = *serviceType
+
+	return service, nil
+}
+
+// handlerServiceType checks that signature is a handler, ie func(ctx C, input I) (O, error), and returns the
+// kind of service that accepts C
+func handlerServiceType(signature *types.Signature) (codegen.ServiceType, bool) {
+	if signature.Params().Len() != 2 || signature.Results().Len() != 2 {
+		return 0, false
+	}
+	if !types.Identical(signature.Results().At(1).Type(), types.Universe.Lookup("error").Type()) {
+		return 0, false
+	}
+
+	named, ok := signature.Params().At(0).Type().(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != codegen.SDKImportPath {
+		return 0, false
+	}
+
+	switch named.Obj().Name() {
+	case "Context":
+		return codegen.ServiceTypeService, true
+	case "ObjectContext", "ObjectSharedContext":
+		return codegen.ServiceTypeObject, true
+	case "WorkflowContext", "WorkflowSharedContext":
+		return codegen.ServiceTypeWorkflow, true
+	default:
+		return 0, false
+	}
+}
+
+// serviceNameOf evaluates the ServiceName method of typeName, if there is one. It must return a constant.
+func serviceNameOf(files []*ast.File, info *types.Info, typeName string) (string, bool, error) {
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Name.Name != "ServiceName" || fn.Recv == nil || len(fn.Recv.List) != 1 || receiverName(fn.Recv.List[0].Type) != typeName {
+				continue
+			}
+			if fn.Body != nil && len(fn.Body.List) == 1 {
+				if ret, ok := fn.Body.List[0].(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+					if value := info.Types[ret.Results[0]].Value; value != nil && value.Kind() == constant.String {
+						return constant.StringVal(value), true, nil
+					}
+				}
+			}
+			return "", false, errors.New(typeName + ".ServiceName must consist of a single return of a constant string")
+		}
+	}
+	return "", false, nil
+}
+
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// imports tracks the packages referred to by the generated code, giving each a unique name
+type imports struct {
+	pkg   *types.Package
+	names map[string]string
+	used  map[string]bool
+	order []string
+}
+
+func newImports(pkg *types.Package) *imports {
+	return &imports{pkg: pkg, names: map[string]string{}, used: map[string]bool{}}
+}
+
+func (i *imports) qualifier(pkg *types.Package) string {
+	if pkg == i.pkg {
+		return ""
+	}
+	return i.name(pkg.Path(), pkg.Name())
+}
+
+func (i *imports) qualify(importPath, name string) string {
+	switch importPath {
+	case codegen.SDKImportPath:
+		return i.name(importPath, "restate") + "." + name
+	default:
+		return i.name(importPath, filepath.Base(importPath)) + "." + name
+	}
+}
+
+func (i *imports) name(importPath, preferred string) string {
+	if name, ok := i.names[importPath]; ok {
+		return name
+	}
+	name := preferred
+	for n := 2; i.used[name] || (i.pkg != nil && i.pkg.Scope().Lookup(name) != nil); n++ {
+		name = preferred + strconv.Itoa(n)
+	}
+	i.names[importPath] = name
+	i.used[name] = true
+	i.order = append(i.order, importPath)
+	return name
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestExample checks that the clients in the example package are up to date with restate-gen
+func TestExample(t *testing.T) {
+	dir, err := filepath.Abs(filepath.Join("..", "example"))
+	require.NoError(t, err)
+
+	// the output is relative to the package directory, but its base name is the one excluded from the package
+	output, err := filepath.Rel(dir, filepath.Join(t.TempDir(), "restate_clients.go"))
+	require.NoError(t, err)
+	require.NoError(t, run(dir, []string{"checkout", "userSession", "ticketService"}, output))
+
+	expected, err := os.ReadFile(filepath.Join(dir, "restate_clients.go"))
+	require.NoError(t, err)
+	generated, err := os.ReadFile(filepath.Join(dir, output))
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(generated), "example/restate_clients.go is out of date; run go generate ./example")
+}
@@ -0,0 +1,152 @@
+package restatetest
+
+import (
+	"context"
+	"fmt"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/internal/errors"
+	"github.com/restatedev/sdk-go/internal/state"
+	"github.com/restatedev/sdk-go/internal/wire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NondeterminismError is returned by [Runtime.CheckDeterminism] when a replay of the journal
+// did not correspond to the journal recorded by the first attempt
+type NondeterminismError struct {
+	// ReplayedEntries is the number of recorded entries (excluding the input entry) that were replayed
+	ReplayedEntries int
+	// Suspended is set if the input was closed after the replayed entries, forcing a suspension
+	Suspended bool
+	// EntryIndex is the index of the first divergent entry
+	EntryIndex uint32
+	// Message describes the divergence, in the same format as the journal mismatch error sent to Restate
+	Message string
+}
+
+func (e *NondeterminismError) Error() string {
+	mode := "replaying"
+	if e.Suspended {
+		mode = "suspending after"
+	}
+	return fmt.Sprintf("nondeterminism detected when %s %d entries: %s", mode, e.ReplayedEntries, e.Message)
+}
+
+// CheckDeterminism runs handler once against the fake runtime, recording its journal, and then replays
+// every prefix of that journal in a new attempt, once with the runtime continuing to complete entries
+// and once with the input closed after the prefix, which forces a suspension at the next blocking operation.
+// Each replay must produce the same entries as the recording, including the output entry.
+//
+// A nil error means that no divergence was found. A [*NondeterminismError] describes the first divergence;
+// any other error means that the protocol could not be driven.
+//
+// Side effects passed to [restate.Run] may return a different result each time they are executed; in that case
+// the entries after the side effect are not compared. Fields which are expected to change between attempts, such as the wake up time
+// of a sleep, are ignored.
+func (r *Runtime) CheckDeterminism(ctx context.Context, handler restate.Handler, input []byte) error {
+	recording, err := r.invoke(ctx, handler, input, nil, false)
+	if err != nil {
+		return err
+	}
+	if recording.errorMessage != nil {
+		return fmt.Errorf("recording attempt failed: %w", recording.result.Error)
+	}
+
+	recorded := recording.entries
+	for k := 0; k <= len(recorded); k++ {
+		for _, closeInput := range []bool{false, true} {
+			if err := r.checkReplay(ctx, handler, input, recording, recorded[:k], closeInput); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Runtime) checkReplay(ctx context.Context, handler restate.Handler, input []byte, recording *invocation, replay []wire.Message, closeInput bool) error {
+	replayed, err := r.invoke(ctx, handler, input, replay, closeInput)
+	if err != nil {
+		return err
+	}
+
+	nondeterminism := func(entryIndex uint32, message string) error {
+		return &NondeterminismError{
+			ReplayedEntries: len(replay),
+			Suspended:       closeInput,
+			EntryIndex:      entryIndex,
+			Message:         message,
+		}
+	}
+
+	if msg := replayed.errorMessage; msg != nil {
+		switch errors.Code(msg.Code) {
+		case errors.ErrJournalMismatch, errors.ErrProtocolViolation:
+			var entryIndex uint32
+			if msg.RelatedEntryIndex != nil {
+				entryIndex = *msg.RelatedEntryIndex
+			}
+			return nondeterminism(entryIndex, msg.Message)
+		}
+	}
+
+	expected := recording.entries[len(replay):]
+	for i, actual := range replayed.entries {
+		entryIndex := uint32(1 + len(replay) + i)
+		if i >= len(expected) {
+			return nondeterminism(entryIndex, fmt.Sprintf("The journal entry at position %d was not present in the recorded journal: type: %T", entryIndex, actual))
+		}
+		if !proto.Equal(comparableEntry(expected[i]), comparableEntry(actual)) {
+			return nondeterminism(entryIndex, state.EntryMismatchMessage(entryIndex, actual, expected[i]))
+		}
+		if _, run := actual.(*wire.RunEntryMessage); run && !proto.Equal(expected[i].ProtoReflect().Interface(), actual.ProtoReflect().Interface()) {
+			// the side effect was executed again and returned a different result, so the rest of
+			// the handler can legitimately diverge from the recording
+			return nil
+		}
+	}
+
+	if closeInput {
+		// the replay may legitimately stop early, at the first operation which needs a completion
+		return nil
+	}
+
+	if len(replayed.entries) < len(expected) {
+		entryIndex := uint32(1 + len(replay) + len(replayed.entries))
+		return nondeterminism(entryIndex, fmt.Sprintf("The journal entry at position %d was not produced by the user code: type: %T", entryIndex, expected[len(replayed.entries)]))
+	}
+
+	return nil
+}
+
+// comparableEntry returns a copy of msg without the fields which are expected to differ between attempts:
+// results delivered by the runtime or produced by side effects, and fields derived from the current time
+func comparableEntry(msg wire.Message) proto.Message {
+	clone := proto.Clone(msg.ProtoReflect().Interface())
+	reflect := clone.ProtoReflect()
+	descriptor := reflect.Descriptor()
+
+	if _, completeable := msg.(wire.CompleteableMessage); completeable {
+		clearOneof(reflect, descriptor.Oneofs().ByName("result"))
+	}
+	if _, run := msg.(*wire.RunEntryMessage); run {
+		clearOneof(reflect, descriptor.Oneofs().ByName("result"))
+	}
+	for _, name := range []protoreflect.Name{"wake_up_time", "invoke_time", "winning_entry_index"} {
+		if field := descriptor.Fields().ByName(name); field != nil {
+			reflect.Clear(field)
+		}
+	}
+
+	return clone
+}
+
+func clearOneof(msg protoreflect.Message, oneof protoreflect.OneofDescriptor) {
+	if oneof == nil {
+		return
+	}
+	if field := msg.WhichOneof(oneof); field != nil {
+		msg.Clear(field)
+	}
+}
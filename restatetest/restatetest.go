@@ -0,0 +1,529 @@
+// Package restatetest provides an in-process fake of the Restate runtime, so that handlers can be
+// exercised with `go test` alone. The fake drives a handler through the service protocol exactly as
+// Restate would, completing calls, sleeps, awakeables, promises and state lookups from user-supplied fakes.
+package restatetest
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"testing"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/internal/errors"
+	"github.com/restatedev/sdk-go/internal/futures"
+	"github.com/restatedev/sdk-go/internal/state"
+	"github.com/restatedev/sdk-go/internal/wire"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultInvocationID is the invocation ID used when [Runtime.ID] is not set. As the random source
+// of the handler is seeded from the invocation ID, this ensures that invocations are reproducible.
+var DefaultInvocationID = []byte("restatetest-invocation-id")
+
+// CallInvocationID returns the ID that the runtime gives to the invocation started by the call or one-way call
+// entry at callEntryIndex of the invocation with invocationID
+func CallInvocationID(invocationID []byte, callEntryIndex uint32) string {
+	return fmt.Sprintf("%s-call-%d", invocationID, callEntryIndex)
+}
+
+// Completion is a result that the fake runtime delivers to the handler for a journal entry.
+// A nil *Completion means that the runtime has no result to deliver (yet).
+type Completion struct {
+	// Value is the successful result
+	Value []byte
+	// Err is the failure result. The code is taken from [restate.ErrorCode]
+	Err error
+}
+
+// empty is delivered for entries which complete without a value, eg a sleep that has elapsed
+var empty = &Completion{}
+
+// Pending may be returned by the fakes for an entry which should stay uncompleted while the runtime keeps sending
+// results for later entries, eg to race a call against the deadline of the handler. Unlike a nil *Completion, the
+// input is not closed, so the handler blocks rather than suspends if it waits on the entry alone.
+var Pending = &Completion{}
+
+// Value returns a successful [Completion] with the provided bytes
+func Value(value []byte) *Completion {
+	return &Completion{Value: value}
+}
+
+// Failure returns a failed [Completion] with the provided error
+func Failure(err error) *Completion {
+	return &Completion{Err: err}
+}
+
+// JSON encodes v as JSON, failing t if it cannot be encoded, to build inputs and completions for handlers using the
+// default codec
+func JSON(t testing.TB, v any) []byte {
+	t.Helper()
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to encode %v as JSON: %v", v, err)
+	}
+	return bytes
+}
+
+// Target identifies the handler that an outbound call is addressed to
+type Target struct {
+	Service string
+	Key     string
+	Handler string
+}
+
+// Runtime is a fake Restate runtime. The zero value is ready to use, in which case sleeps complete
+// immediately and all other blocking operations are never completed.
+//
+// Whenever the handler creates an entry that the fakes cannot complete, the runtime behaves like a
+// Restate runtime that has nothing more to send: the input stream is closed, and the invocation will
+// suspend at the next blocking operation that has not been completed.
+type Runtime struct {
+	// ID is the invocation ID; defaults to [DefaultInvocationID]
+	ID []byte
+	// ProtocolVersion is the negotiated service protocol version; defaults to the latest supported version
+	ProtocolVersion protocol.ServiceProtocolVersion
+	// Key is the Virtual Object or Workflow key
+	Key string
+	// Headers are the headers of the incoming request
+	Headers map[string]string
+	// AttemptHeaders are the attempt specific headers sent by the runtime
+	AttemptHeaders map[string][]string
+	// State is the state of the Virtual Object or Workflow, which is eagerly sent to the handler
+	State map[string][]byte
+	// LazyState makes the runtime send no state with the invocation, so that every key is fetched on demand
+	LazyState bool
+	// Promises contains the durable promises of a Workflow that are already completed
+	Promises map[string]*Completion
+	// Invocations contains the outputs of other invocations that have already completed, by invocation ID
+	Invocations map[string]*Completion
+
+	// Call is consulted for the result of a request-response call
+	Call func(target Target, input []byte) *Completion
+	// Sleep is consulted for the result of a sleep. If not set, sleeps complete immediately
+	Sleep func(wakeUpTime time.Time) *Completion
+	// Awakeable is consulted for the result of an awakeable with the provided ID
+	Awakeable func(id string) *Completion
+
+	// Middleware is applied to the handler in the same way as middleware provided to the server
+	Middleware []restate.Middleware
+
+	// Logger is the slog handler used by the SDK; defaults to slog.Default().Handler()
+	Logger slog.Handler
+	// DropReplayLogs controls whether logs produced by the handler during replay are dropped
+	DropReplayLogs bool
+}
+
+// Result is the outcome of a single attempt of an invocation
+type Result struct {
+	// Journal contains the entries written by the handler, in order, excluding the input entry.
+	// Entries are the messages of the [protocol] package, for example *protocol.CallEntryMessage,
+	// and include any result the runtime completed them with.
+	Journal []proto.Message
+	// Output is the successful output of the handler
+	Output []byte
+	// TerminalError is set if the handler returned a terminal error
+	TerminalError error
+	// Error is set if the attempt failed with a retryable error, in which case Restate would retry the invocation
+	Error error
+	// Suspended is set if the invocation suspended, waiting on the entries in SuspendedOn
+	Suspended   bool
+	SuspendedOn []uint32
+	// State is the state of the Virtual Object or Workflow after the attempt
+	State map[string][]byte
+}
+
+// Completed returns true if the handler returned an output or a terminal error
+func (r *Result) Completed() bool {
+	return r.Error == nil && !r.Suspended
+}
+
+// Invoke runs handler for a single attempt against the fake runtime, returning its output and journal.
+// An error is returned if the protocol could not be driven, not if the handler failed.
+// The handler should already be registered on a service definition (eg with NewService(...).Handler(...)),
+// which sets its default codec.
+func (r *Runtime) Invoke(ctx context.Context, handler restate.Handler, input []byte) (*Result, error) {
+	inv, err := r.invoke(ctx, handler, input, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	return inv.result, nil
+}
+
+// Complete invokes handler like [Runtime.Invoke], failing t unless the attempt completed with an output or a
+// terminal error, and then checks that the invocation replays deterministically with [Runtime.CheckDeterminism].
+func (r *Runtime) Complete(t testing.TB, handler restate.Handler, input []byte) *Result {
+	t.Helper()
+	result, err := r.Invoke(context.Background(), handler, input)
+	if err != nil {
+		t.Fatalf("failed to invoke handler: %v", err)
+	}
+	if !result.Completed() {
+		t.Fatalf("invocation did not complete: suspended %v on %v, error %v", result.Suspended, result.SuspendedOn, result.Error)
+	}
+	if err := r.CheckDeterminism(context.Background(), handler, input); err != nil {
+		t.Fatalf("invocation is not deterministic: %v", err)
+	}
+	return result
+}
+
+// invoke runs a single attempt of handler, first replaying the provided journal entries. If closeInput is set,
+// the input is closed after the replayed entries, and so no completions will be delivered.
+func (r *Runtime) invoke(ctx context.Context, handler restate.Handler, input []byte, replay []wire.Message, closeInput bool) (*invocation, error) {
+	id := r.ID
+	if id == nil {
+		id = DefaultInvocationID
+	}
+	logger := r.Logger
+	if logger == nil {
+		logger = slog.Default().Handler()
+	}
+
+	toMachineReader, toMachineWriter := io.Pipe()
+	fromMachineReader, fromMachineWriter := io.Pipe()
+
+	protocolVersion := r.ProtocolVersion
+	if protocolVersion == protocol.ServiceProtocolVersion_SERVICE_PROTOCOL_VERSION_UNSPECIFIED {
+		protocolVersion = protocol.ServiceProtocolVersion_V3
+	}
+
+	machine := state.NewMachine(handler, conn{toMachineReader, fromMachineWriter}, protocolVersion, r.AttemptHeaders)
+	machine.Use(r.Middleware...)
+
+	machineErr := make(chan error, 1)
+	go func() {
+		err := machine.Start(ctx, r.DropReplayLogs, logger)
+		fromMachineWriter.CloseWithError(fmt.Errorf("invocation ended without an end, error or suspension message: %w", io.ErrUnexpectedEOF))
+		// unblock any pending write of a completion
+		toMachineReader.Close()
+		machineErr <- err
+	}()
+
+	inv := &invocation{
+		runtime:    r,
+		id:         id,
+		toMachine:  wire.NewProtocol(conn{Writer: toMachineWriter}),
+		input:      toMachineWriter,
+		entryIndex: uint32(1 + len(replay)),
+		result: &Result{
+			State: make(map[string][]byte, len(r.State)),
+		},
+		promises: make(map[string]*Completion, len(r.Promises)),
+	}
+	for k, v := range r.State {
+		inv.result.State[k] = v
+	}
+	for k, v := range r.Promises {
+		inv.promises[k] = v
+	}
+
+	if err := inv.start(input, replay); err != nil {
+		if startErr := <-machineErr; startErr != nil {
+			return nil, startErr
+		}
+		return nil, err
+	}
+	if closeInput {
+		inv.close()
+	}
+
+	err := inv.process(wire.NewProtocol(conn{Reader: fromMachineReader}))
+	toMachineWriter.Close()
+	if startErr := <-machineErr; startErr != nil {
+		return nil, startErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return inv, nil
+}
+
+type conn struct {
+	io.Reader
+	io.Writer
+}
+
+func (c conn) Write(data []byte) (int, error) {
+	if len(data) == 0 {
+		// a pipe would block on empty writes until the other side reads, but empty message bodies are never read
+		return 0, nil
+	}
+	return c.Writer.Write(data)
+}
+
+type invocation struct {
+	runtime    *Runtime
+	id         []byte
+	toMachine  *wire.Protocol
+	input      io.Closer
+	closed     bool
+	entryIndex uint32
+	result     *Result
+	promises   map[string]*Completion
+
+	// entries written by the handler in this attempt
+	entries []wire.Message
+	// set if the attempt ended with an error message
+	errorMessage *wire.ErrorMessage
+}
+
+func (i *invocation) start(input []byte, replay []wire.Message) error {
+	stateMap := make([]*protocol.StartMessage_StateEntry, 0, len(i.result.State))
+	if !i.runtime.LazyState {
+		for k, v := range i.result.State {
+			stateMap = append(stateMap, &protocol.StartMessage_StateEntry{Key: []byte(k), Value: v})
+		}
+	}
+	sort.Slice(stateMap, func(a, b int) bool { return string(stateMap[a].Key) < string(stateMap[b].Key) })
+
+	headers := make([]*protocol.Header, 0, len(i.runtime.Headers))
+	for k, v := range i.runtime.Headers {
+		headers = append(headers, &protocol.Header{Key: k, Value: v})
+	}
+
+	if err := i.toMachine.Write(wire.StartMessageType, &wire.StartMessage{
+		StartMessage: protocol.StartMessage{
+			Id:           i.id,
+			DebugId:      string(i.id),
+			KnownEntries: uint32(1 + len(replay)),
+			StateMap:     stateMap,
+			PartialState: i.runtime.LazyState,
+			Key:          i.runtime.Key,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to write start message: %w", err)
+	}
+
+	if err := i.toMachine.Write(wire.InputEntryMessageType, &wire.InputEntryMessage{
+		InputEntryMessage: protocol.InputEntryMessage{
+			Headers: headers,
+			Value:   input,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to write input message: %w", err)
+	}
+
+	for _, entry := range replay {
+		i.apply(entry)
+		if err := i.toMachine.Write(wire.MessageType(entry), entry); err != nil {
+			return fmt.Errorf("failed to write replayed entry: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (i *invocation) process(fromMachine *wire.Protocol) error {
+	for {
+		msg, _, err := fromMachine.Read()
+		if err != nil {
+			return err
+		}
+
+		switch msg := msg.(type) {
+		case *wire.EndMessage:
+			return nil
+		case *wire.SuspensionMessage:
+			i.result.Suspended = true
+			i.result.SuspendedOn = msg.EntryIndexes
+			return nil
+		case *wire.ErrorMessage:
+			i.errorMessage = msg
+			i.result.Error = &errors.CodeError{Code: errors.Code(msg.Code), Inner: stderrors.New(msg.Message)}
+			return nil
+		}
+
+		entryIndex := i.entryIndex
+		i.entryIndex++
+		i.entries = append(i.entries, msg)
+		i.result.Journal = append(i.result.Journal, msg.ProtoReflect().Interface())
+
+		i.apply(msg)
+		if i.closed {
+			// no completions can be sent
+			continue
+		}
+		if err := i.entry(entryIndex, msg); err != nil {
+			return err
+		}
+	}
+}
+
+// apply records the effect of an entry on the result of the invocation
+func (i *invocation) apply(msg wire.Message) {
+	switch msg := msg.(type) {
+	case *wire.OutputEntryMessage:
+		switch result := msg.Result.(type) {
+		case *protocol.OutputEntryMessage_Value:
+			i.result.Output = result.Value
+		case *protocol.OutputEntryMessage_Failure:
+			i.result.TerminalError = errors.ErrorFromFailure(result.Failure)
+		}
+	case *wire.SetStateEntryMessage:
+		i.result.State[string(msg.Key)] = msg.Value
+	case *wire.ClearStateEntryMessage:
+		delete(i.result.State, string(msg.Key))
+	case *wire.ClearAllStateEntryMessage:
+		i.result.State = map[string][]byte{}
+	case *wire.CompletePromiseEntryMessage:
+		if _, ok := msg.Result.(*protocol.CompletePromiseEntryMessage_Empty); !ok {
+			return
+		}
+		switch completion := msg.Completion.(type) {
+		case *protocol.CompletePromiseEntryMessage_CompletionValue:
+			i.promises[msg.Key] = Value(completion.CompletionValue)
+		case *protocol.CompletePromiseEntryMessage_CompletionFailure:
+			i.promises[msg.Key] = Failure(errors.ErrorFromFailure(completion.CompletionFailure))
+		}
+	}
+}
+
+// entry sends the completion or ack that the runtime would send for a new entry
+func (i *invocation) entry(entryIndex uint32, msg wire.Message) error {
+	switch msg := msg.(type) {
+	case *wire.GetStateEntryMessage:
+		if msg.Completed() {
+			return nil
+		}
+		if value, ok := i.result.State[string(msg.Key)]; ok {
+			return i.complete(entryIndex, msg, Value(value))
+		}
+		return i.complete(entryIndex, msg, empty)
+	case *wire.GetStateKeysEntryMessage:
+		if msg.Completed() {
+			return nil
+		}
+		keys := make([][]byte, 0, len(i.result.State))
+		for k := range i.result.State {
+			keys = append(keys, []byte(k))
+		}
+		sort.Slice(keys, func(a, b int) bool { return string(keys[a]) < string(keys[b]) })
+		value, err := proto.Marshal(&protocol.GetStateKeysEntryMessage_StateKeys{Keys: keys})
+		if err != nil {
+			return err
+		}
+		return i.complete(entryIndex, msg, Value(value))
+	case *wire.GetPromiseEntryMessage:
+		return i.complete(entryIndex, msg, i.promises[msg.Key])
+	case *wire.PeekPromiseEntryMessage:
+		if completion, ok := i.promises[msg.Key]; ok {
+			return i.complete(entryIndex, msg, completion)
+		}
+		return i.complete(entryIndex, msg, empty)
+	case *wire.CompletePromiseEntryMessage:
+		if _, ok := i.promises[msg.Key]; ok {
+			return i.complete(entryIndex, msg, Failure(restate.TerminalError(fmt.Errorf("promise %s already completed", msg.Key), 409)))
+		}
+		if err := i.complete(entryIndex, msg, empty); err != nil {
+			return err
+		}
+		i.apply(msg)
+		return nil
+	case *wire.SleepEntryMessage:
+		if i.runtime.Sleep == nil {
+			return i.complete(entryIndex, msg, empty)
+		}
+		return i.complete(entryIndex, msg, i.runtime.Sleep(time.UnixMilli(int64(msg.WakeUpTime))))
+	case *wire.CallEntryMessage:
+		if i.runtime.Call == nil {
+			return i.complete(entryIndex, msg, nil)
+		}
+		return i.complete(entryIndex, msg, i.runtime.Call(Target{msg.ServiceName, msg.Key, msg.HandlerName}, msg.Parameter))
+	case *wire.AwakeableEntryMessage:
+		if i.runtime.Awakeable == nil {
+			return i.complete(entryIndex, msg, nil)
+		}
+		return i.complete(entryIndex, msg, i.runtime.Awakeable(futures.AwakeableID(i.id, entryIndex)))
+	case *wire.AttachInvocationEntryMessage:
+		return i.complete(entryIndex, msg, i.runtime.Invocations[msg.GetInvocationId()])
+	case *wire.GetInvocationOutputEntryMessage:
+		if completion, ok := i.runtime.Invocations[msg.GetInvocationId()]; ok {
+			return i.complete(entryIndex, msg, completion)
+		}
+		return i.complete(entryIndex, msg, empty)
+	case *wire.GetCallInvocationIdEntryMessage:
+		return i.complete(entryIndex, msg, Value([]byte(CallInvocationID(i.id, msg.CallEntryIndex))))
+	case *wire.RunEntryMessage, *wire.SelectorEntryMessage:
+		return i.ack(entryIndex)
+	}
+
+	return nil
+}
+
+// complete sends a completion for the entry at entryIndex, which is also applied to the journal copy of
+// the entry. A nil completion means that the entry will not be completed, and so the input is closed.
+func (i *invocation) complete(entryIndex uint32, msg wire.CompleteableMessage, completion *Completion) error {
+	if completion == nil {
+		i.close()
+		return nil
+	}
+	if i.closed || completion == Pending {
+		return nil
+	}
+
+	completionMessage := &wire.CompletionMessage{
+		CompletionMessage: protocol.CompletionMessage{
+			EntryIndex: entryIndex,
+		},
+	}
+	switch {
+	case completion == empty:
+		completionMessage.Result = &protocol.CompletionMessage_Empty{Empty: &protocol.Empty{}}
+	case completion.Err != nil:
+		completionMessage.Result = &protocol.CompletionMessage_Failure{Failure: &protocol.Failure{
+			Code:    uint32(restate.ErrorCode(completion.Err)),
+			Message: failureMessage(completion.Err),
+		}}
+	default:
+		completionMessage.Result = &protocol.CompletionMessage_Value{Value: completion.Value}
+	}
+
+	if err := msg.Complete(&completionMessage.CompletionMessage); err != nil {
+		return fmt.Errorf("invalid completion for entry %d: %w", entryIndex, err)
+	}
+
+	if err := i.toMachine.Write(wire.CompletionMessageType, completionMessage); err != nil {
+		return fmt.Errorf("failed to write completion message: %w", err)
+	}
+
+	return nil
+}
+
+func (i *invocation) ack(entryIndex uint32) error {
+	if i.closed {
+		return nil
+	}
+
+	if err := i.toMachine.Write(wire.EntryAckMessageType, &wire.EntryAckMessage{
+		EntryAckMessage: protocol.EntryAckMessage{
+			EntryIndex: entryIndex,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to write ack message: %w", err)
+	}
+
+	return nil
+}
+
+func (i *invocation) close() {
+	if !i.closed {
+		i.closed = true
+		i.input.Close()
+	}
+}
+
+// failureMessage avoids the code of err being repeated in the message, as it is sent separately
+func failureMessage(err error) string {
+	var codeErr *errors.CodeError
+	if stderrors.As(err, &codeErr) {
+		return codeErr.Inner.Error()
+	}
+	return err.Error()
+}
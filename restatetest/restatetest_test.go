@@ -0,0 +1,213 @@
+package restatetest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceCallRunAndSleep(t *testing.T) {
+	handler := restate.NewServiceHandler(func(ctx restate.Context, name string) (string, error) {
+		greeting, err := restate.CallAs[string](ctx.Service("Greeter", "greet")).Request(name)
+		if err != nil {
+			return "", err
+		}
+		if err := ctx.Sleep(time.Second); err != nil {
+			return "", err
+		}
+		return restate.RunAs(ctx, func(ctx restate.RunContext) (string, error) {
+			return greeting + "!", nil
+		})
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	runtime := restatetest.Runtime{
+		Call: func(target restatetest.Target, input []byte) *restatetest.Completion {
+			require.Equal(t, restatetest.Target{Service: "Greeter", Handler: "greet"}, target)
+			var name string
+			require.NoError(t, json.Unmarshal(input, &name))
+			return restatetest.Value(restatetest.JSON(t, "hello "+name))
+		},
+	}
+
+	result, err := runtime.Invoke(context.Background(), handler, restatetest.JSON(t, "bob"))
+	require.NoError(t, err)
+	require.True(t, result.Completed())
+	require.NoError(t, result.TerminalError)
+	require.Equal(t, restatetest.JSON(t, "hello bob!"), result.Output)
+
+	require.Len(t, result.Journal, 4)
+	require.IsType(t, &protocol.CallEntryMessage{}, result.Journal[0])
+	require.IsType(t, &protocol.SleepEntryMessage{}, result.Journal[1])
+	require.IsType(t, &protocol.RunEntryMessage{}, result.Journal[2])
+	require.IsType(t, &protocol.OutputEntryMessage{}, result.Journal[3])
+}
+
+func TestObjectState(t *testing.T) {
+	handler := restate.NewObjectHandler(func(ctx restate.ObjectContext, delta int) (int, error) {
+		count, err := restate.GetAs[int](ctx, "count")
+		if err != nil && err != restate.ErrKeyNotFound {
+			return 0, err
+		}
+		count += delta
+		ctx.Clear("stale")
+		return count, ctx.Set("count", count)
+	})
+	restate.NewObject("Counter").Handler("add", handler)
+
+	runtime := restatetest.Runtime{
+		Key: "my-counter",
+		State: map[string][]byte{
+			"count": restatetest.JSON(t, 1),
+			"stale": restatetest.JSON(t, true),
+		},
+	}
+
+	result, err := runtime.Invoke(context.Background(), handler, restatetest.JSON(t, 2))
+	require.NoError(t, err)
+	require.True(t, result.Completed())
+	require.Equal(t, restatetest.JSON(t, 3), result.Output)
+	require.Equal(t, map[string][]byte{"count": restatetest.JSON(t, 3)}, result.State)
+}
+
+func TestAwakeable(t *testing.T) {
+	handler := restate.NewServiceHandler(func(ctx restate.Context, _ restate.Void) (string, error) {
+		return restate.AwakeableAs[string](ctx).Result()
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	t.Run("resolved", func(t *testing.T) {
+		var resolvedID string
+		runtime := restatetest.Runtime{
+			Awakeable: func(id string) *restatetest.Completion {
+				resolvedID = id
+				return restatetest.Value(restatetest.JSON(t, "woken"))
+			},
+		}
+
+		result, err := runtime.Invoke(context.Background(), handler, nil)
+		require.NoError(t, err)
+		require.Equal(t, restatetest.JSON(t, "woken"), result.Output)
+		require.Regexp(t, "^prom_1", resolvedID)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		runtime := restatetest.Runtime{
+			Awakeable: func(id string) *restatetest.Completion {
+				return restatetest.Failure(restate.TerminalError(fmt.Errorf("rejected"), 400))
+			},
+		}
+
+		result, err := runtime.Invoke(context.Background(), handler, nil)
+		require.NoError(t, err)
+		require.True(t, result.Completed())
+		require.EqualValues(t, 400, restate.ErrorCode(result.TerminalError))
+		require.ErrorContains(t, result.TerminalError, "rejected")
+	})
+
+	t.Run("suspends", func(t *testing.T) {
+		var runtime restatetest.Runtime
+
+		result, err := runtime.Invoke(context.Background(), handler, nil)
+		require.NoError(t, err)
+		require.False(t, result.Completed())
+		require.True(t, result.Suspended)
+		require.Equal(t, []uint32{1}, result.SuspendedOn)
+	})
+}
+
+func TestRetryableError(t *testing.T) {
+	handler := restate.NewServiceHandler(func(ctx restate.Context, _ restate.Void) (restate.Void, error) {
+		return restate.Void{}, fmt.Errorf("try again, 100%% busy")
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	var runtime restatetest.Runtime
+	result, err := runtime.Invoke(context.Background(), handler, nil)
+	require.NoError(t, err)
+	require.False(t, result.Completed())
+	require.EqualError(t, result.Error, "[500] try again, 100% busy")
+	require.Empty(t, result.Journal)
+}
+
+func TestCheckDeterminism(t *testing.T) {
+	runtime := restatetest.Runtime{
+		Key: "my-counter",
+		Call: func(target restatetest.Target, input []byte) *restatetest.Completion {
+			return restatetest.Value(input)
+		},
+	}
+
+	t.Run("deterministic", func(t *testing.T) {
+		handler := restate.NewObjectHandler(func(ctx restate.ObjectContext, name string) (string, error) {
+			greeting, err := restate.CallAs[string](ctx.Service("Greeter", "greet")).Request(name)
+			if err != nil {
+				return "", err
+			}
+			if err := ctx.Sleep(time.Second); err != nil {
+				return "", err
+			}
+			now, err := restate.RunAs(ctx, func(ctx restate.RunContext) (int64, error) {
+				return time.Now().UnixNano(), nil
+			})
+			if err != nil {
+				return "", err
+			}
+			if err := ctx.Set("last", now); err != nil {
+				return "", err
+			}
+			return greeting, nil
+		})
+		restate.NewObject("Test").Handler("handle", handler)
+
+		require.NoError(t, runtime.CheckDeterminism(context.Background(), handler, restatetest.JSON(t, "bob")))
+	})
+
+	t.Run("nondeterministic", func(t *testing.T) {
+		var attempt int
+		handler := restate.NewObjectHandler(func(ctx restate.ObjectContext, name string) (string, error) {
+			attempt++
+			if err := ctx.Set("attempt", attempt); err != nil {
+				return "", err
+			}
+			return restate.CallAs[string](ctx.Service("Greeter", "greet")).Request(name)
+		})
+		restate.NewObject("Test").Handler("handle", handler)
+
+		err := runtime.CheckDeterminism(context.Background(), handler, restatetest.JSON(t, "bob"))
+		var nondeterminism *restatetest.NondeterminismError
+		require.ErrorAs(t, err, &nondeterminism)
+		require.Equal(t, 0, nondeterminism.ReplayedEntries)
+		require.EqualValues(t, 1, nondeterminism.EntryIndex)
+		require.Contains(t, nondeterminism.Message, "Journal mismatch")
+	})
+
+	t.Run("nondeterministic after sleep", func(t *testing.T) {
+		var attempt int
+		handler := restate.NewObjectHandler(func(ctx restate.ObjectContext, name string) (string, error) {
+			attempt++
+			if attempt > 1 {
+				name = "alice"
+			}
+			if err := ctx.Sleep(time.Second); err != nil {
+				return "", err
+			}
+			return restate.CallAs[string](ctx.Service("Greeter", "greet", restate.WithName("greet-user"))).Request(name)
+		})
+		restate.NewObject("Test").Handler("handle", handler)
+
+		err := runtime.CheckDeterminism(context.Background(), handler, restatetest.JSON(t, "bob"))
+		var nondeterminism *restatetest.NondeterminismError
+		require.ErrorAs(t, err, &nondeterminism)
+		require.EqualValues(t, 2, nondeterminism.EntryIndex)
+		require.Contains(t, nondeterminism.Message, "*wire.CallEntryMessage")
+		require.Contains(t, nondeterminism.Message, `name: "greet-user"`)
+	})
+}
@@ -49,6 +49,10 @@ func (r *service) Handler(name string, handler ServiceHandler) *service {
 	if handler.getOptions().Codec == nil {
 		handler.getOptions().Codec = r.options.DefaultCodec
 	}
+	if len(r.options.Middleware) > 0 {
+		// service middleware runs before handler middleware
+		handler = serviceMiddlewareHandler{handler, r.options.Middleware}
+	}
 	r.handlers[name] = handler
 	return r
 }
@@ -98,6 +102,10 @@ func (r *object) Handler(name string, handler ObjectHandler) *object {
 	if handler.getOptions().Codec == nil {
 		handler.getOptions().Codec = r.options.DefaultCodec
 	}
+	if len(r.options.Middleware) > 0 {
+		// object middleware runs before handler middleware
+		handler = objectMiddlewareHandler{handler, r.options.Middleware}
+	}
 	r.handlers[name] = handler
 	return r
 }
@@ -107,7 +115,75 @@ func (r *object) Handlers() map[string]Handler {
 	return r.handlers
 }
 
+// LazyState returns whether this Virtual Object was created with [WithLazyState], or nil to use the default of the
+// server
+func (r *object) LazyState() *bool {
+	return r.options.LazyState
+}
+
 // Type implements [ServiceDefinition] by returning [internal.ServiceType_VIRTUAL_OBJECT]
 func (r *object) Type() internal.ServiceType {
 	return internal.ServiceType_VIRTUAL_OBJECT
 }
+
+// workflow stores a list of handlers under a named Workflow
+type workflow struct {
+	name     string
+	handlers map[string]Handler
+	options  options.WorkflowOptions
+}
+
+var _ ServiceDefinition = &workflow{}
+
+// NewWorkflow creates a new named Workflow. A Workflow should have exactly one handler created
+// with [NewWorkflowHandler] (conventionally named "run") which is executed once per workflow ID,
+// and may have any number of shared handlers created with [NewWorkflowSharedHandler] which can
+// interact with the workflow while it is running. Binding a Workflow without exactly one such handler to a server
+// panics.
+func NewWorkflow(name string, opts ...options.WorkflowOption) *workflow {
+	o := options.WorkflowOptions{}
+	for _, opt := range opts {
+		opt.BeforeWorkflow(&o)
+	}
+	if o.DefaultCodec == nil {
+		o.DefaultCodec = encoding.JSONCodec
+	}
+	return &workflow{
+		name:     name,
+		handlers: make(map[string]Handler),
+		options:  o,
+	}
+}
+
+// Name returns the name of this Workflow
+func (r *workflow) Name() string {
+	return r.name
+}
+
+// Handler registers a new Workflow handler by name
+func (r *workflow) Handler(name string, handler WorkflowHandler) *workflow {
+	if handler.getOptions().Codec == nil {
+		handler.getOptions().Codec = r.options.DefaultCodec
+	}
+	if len(r.options.Middleware) > 0 {
+		// workflow middleware runs before handler middleware
+		handler = workflowMiddlewareHandler{handler, r.options.Middleware}
+	}
+	r.handlers[name] = handler
+	return r
+}
+
+// Handlers returns the list of handlers in this Workflow
+func (r *workflow) Handlers() map[string]Handler {
+	return r.handlers
+}
+
+// LazyState returns whether this Workflow was created with [WithLazyState], or nil to use the default of the server
+func (r *workflow) LazyState() *bool {
+	return r.options.LazyState
+}
+
+// Type implements [ServiceDefinition] by returning [internal.ServiceType_WORKFLOW]
+func (r *workflow) Type() internal.ServiceType {
+	return internal.ServiceType_WORKFLOW
+}
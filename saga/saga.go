@@ -0,0 +1,84 @@
+// Package saga helps handlers made of several steps to undo the steps that completed when a later one fails. Each
+// step registers a compensation, and if the handler ends with a terminal error, including the cancellation of the
+// invocation, the compensations are run in the reverse order of registration, each as its own Run so that they
+// are executed exactly once even if the invocation is retried.
+//
+//	func (c *checkout) Payment(ctx restate.Context, request PaymentRequest) (response PaymentResponse, err error) {
+//		s := saga.New(ctx)
+//		defer func() { err = s.Finish(err) }()
+//
+//		paymentID, err := saga.Run(s, "charge", func(ctx restate.RunContext) (string, error) {
+//			return payments.Charge(request)
+//		}, func(ctx restate.RunContext) error {
+//			return payments.Refund(request)
+//		})
+//		...
+//	}
+package saga
+
+import (
+	"log/slog"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/internal/log"
+	"github.com/restatedev/sdk-go/internal/options"
+)
+
+// Saga collects the compensations of the steps of a handler
+type Saga struct {
+	ctx           restate.Context
+	compensations []compensation
+}
+
+type compensation struct {
+	name string
+	fn   func(ctx restate.RunContext) error
+}
+
+// New creates a Saga for the handler with ctx. Its Finish method should be called with the error that the handler
+// returns, typically in a deferred function.
+func New(ctx restate.Context) *Saga {
+	return &Saga{ctx: ctx}
+}
+
+// Compensate registers fn to undo a step, called name, if the saga fails. Compensations should be registered
+// before the step that they undo is made, as the step may have taken effect even if it failed, and so they must
+// tolerate the step not having taken effect. Like Run functions, they are retried until they succeed or return a
+// terminal error.
+func (s *Saga) Compensate(name string, fn func(ctx restate.RunContext) error) {
+	s.compensations = append(s.compensations, compensation{name, fn})
+}
+
+// Run registers compensate, and then runs fn with [restate.RunAs], in a Run named name unless another name is
+// provided with [restate.WithName].
+func Run[T any](s *Saga, name string, fn func(ctx restate.RunContext) (T, error), compensate func(ctx restate.RunContext) error, opts ...options.RunOption) (T, error) {
+	s.Compensate(name, compensate)
+	return restate.RunAs(s.ctx, fn, append([]options.RunOption{restate.WithName(name)}, opts...)...)
+}
+
+// Call registers compensate, and then makes a call with client and blocks on the response
+func Call[O any](s *Saga, name string, client restate.CallClient, input any, compensate func(ctx restate.RunContext) error) (O, error) {
+	s.Compensate(name, compensate)
+	return restate.CallAs[O](client).Request(input)
+}
+
+// Finish runs the compensations in the reverse order of registration if err is a terminal error, and returns err.
+// Retryable errors are returned without compensating, as Restate will retry the invocation, which will register
+// the same compensations again. If a compensation returns a terminal error, it is logged and the remaining
+// compensations are still run.
+func (s *Saga) Finish(err error) error {
+	if err == nil || !restate.IsTerminalError(err) {
+		return err
+	}
+
+	for i := len(s.compensations) - 1; i >= 0; i-- {
+		c := s.compensations[i]
+		if _, cerr := restate.RunAs(s.ctx, func(ctx restate.RunContext) (restate.Void, error) {
+			return restate.Void{}, c.fn(ctx)
+		}, restate.WithName("compensate "+c.name)); cerr != nil {
+			s.ctx.Log().Error("Compensation failed", slog.String("step", c.name), log.Error(cerr))
+		}
+	}
+	s.compensations = nil
+	return err
+}
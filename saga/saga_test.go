@@ -0,0 +1,120 @@
+package saga_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/restatedev/sdk-go/saga"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func runNames(journal []proto.Message) []string {
+	var names []string
+	for _, entry := range journal {
+		if run, ok := entry.(*protocol.RunEntryMessage); ok {
+			names = append(names, run.Name)
+		}
+	}
+	return names
+}
+
+// newCheckout returns a handler which reserves, pays and ships, failing the shipping step with shipErr, along
+// with the names of the compensations in the order that they were executed
+func newCheckout(shipErr error) (restate.Handler, *[]string) {
+	var compensated []string
+	compensate := func(name string) func(ctx restate.RunContext) error {
+		return func(ctx restate.RunContext) error {
+			compensated = append(compensated, name)
+			if name == "reserve" {
+				return restate.TerminalError(fmt.Errorf("reservation already expired"))
+			}
+			return nil
+		}
+	}
+
+	handler := restate.NewServiceHandler(func(ctx restate.Context, _ restate.Void) (paymentID string, err error) {
+		s := saga.New(ctx)
+		defer func() { err = s.Finish(err) }()
+
+		if _, err := saga.Run(s, "reserve", func(ctx restate.RunContext) (bool, error) {
+			return true, nil
+		}, compensate("reserve")); err != nil {
+			return "", err
+		}
+		paymentID, err = saga.Call[string](s, "pay", ctx.Service("Payments", "charge"), 30, compensate("pay"))
+		if err != nil {
+			return "", err
+		}
+		_, err = saga.Run(s, "ship", func(ctx restate.RunContext) (restate.Void, error) {
+			return restate.Void{}, shipErr
+		}, compensate("ship"))
+		return paymentID, err
+	})
+	restate.NewService("Checkout").Handler("checkout", handler)
+	return handler, &compensated
+}
+
+func TestSaga(t *testing.T) {
+	runtime := restatetest.Runtime{
+		Call: func(target restatetest.Target, input []byte) *restatetest.Completion {
+			return restatetest.Value(restatetest.JSON(t, "payment-1"))
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		handler, compensated := newCheckout(nil)
+		result, err := runtime.Invoke(context.Background(), handler, nil)
+		require.NoError(t, err)
+		require.True(t, result.Completed())
+		require.NoError(t, result.TerminalError)
+		require.Equal(t, restatetest.JSON(t, "payment-1"), result.Output)
+		require.Empty(t, *compensated)
+		require.Equal(t, []string{"reserve", "ship"}, runNames(result.Journal))
+	})
+
+	t.Run("terminal failure", func(t *testing.T) {
+		handler, compensated := newCheckout(restate.TerminalError(fmt.Errorf("out of stock"), 409))
+		result, err := runtime.Invoke(context.Background(), handler, nil)
+		require.NoError(t, err)
+		require.True(t, result.Completed())
+		require.EqualValues(t, 409, restate.ErrorCode(result.TerminalError))
+		require.ErrorContains(t, result.TerminalError, "out of stock")
+
+		// the failed compensation of the reservation doesn't replace the original error
+		require.Equal(t, []string{"ship", "pay", "reserve"}, *compensated)
+		require.Equal(t, []string{"reserve", "ship", "compensate ship", "compensate pay", "compensate reserve"}, runNames(result.Journal))
+		require.NoError(t, runtime.CheckDeterminism(context.Background(), handler, nil))
+	})
+
+	t.Run("retryable failure", func(t *testing.T) {
+		handler, compensated := newCheckout(fmt.Errorf("warehouse unavailable"))
+		result, err := runtime.Invoke(context.Background(), handler, nil)
+		require.NoError(t, err)
+		require.False(t, result.Completed())
+		require.ErrorContains(t, result.Error, "warehouse unavailable")
+		require.Empty(t, *compensated)
+	})
+
+	t.Run("cancellation", func(t *testing.T) {
+		handler, compensated := newCheckout(nil)
+		cancelled := restatetest.Runtime{
+			Call: func(target restatetest.Target, input []byte) *restatetest.Completion {
+				return restatetest.Failure(restate.ErrCancelled)
+			},
+		}
+		result, err := cancelled.Invoke(context.Background(), handler, nil)
+		require.NoError(t, err)
+		require.True(t, result.Completed())
+		// the handler fails with the cancellation; its output message is prefixed with the code, so it no longer
+		// matches ErrCancelled
+		require.EqualValues(t, 409, restate.ErrorCode(result.TerminalError))
+		require.ErrorContains(t, result.TerminalError, "canceled")
+		require.Equal(t, []string{"pay", "reserve"}, *compensated)
+		require.NoError(t, cancelled.CheckDeterminism(context.Background(), handler, nil))
+	})
+}
@@ -0,0 +1,208 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed cron expression, which matches the minutes at which a job should run
+type Cron struct {
+	expr                          string
+	minute, hour, dom, month, dow uint64
+	// the day of the month and day of the week match if either does, unless one of them starts with *
+	domStar, dowStar bool
+	location         *time.Location
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is accepted as Sunday, and folded onto 0
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses a standard five field cron expression (minute, hour, day of month, month and day of week), where
+// each field is *, a value, a range a-b or a list of them separated by commas, optionally followed by a step /n.
+// Months and days of the week may also be given by their three letter English names. The descriptors @yearly,
+// @annually, @monthly, @weekly, @daily, @midnight and @hourly are accepted in place of an expression.
+// Times are matched in location, or in UTC if location is nil.
+func ParseCron(expr string, location *time.Location) (*Cron, error) {
+	if location == nil {
+		location = time.UTC
+	}
+	c := &Cron{expr: expr, location: location}
+	spec := expr
+	if descriptor, ok := descriptors[strings.ToLower(strings.TrimSpace(expr))]; ok {
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields, but has %d", expr, len(fields))
+	}
+
+	var err error
+	if c.minute, _, err = minuteField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if c.hour, _, err = hourField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if c.dom, c.domStar, err = domField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if c.month, _, err = monthField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if c.dow, c.dowStar, err = dowField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	return c, nil
+}
+
+// parse returns the set of values matched by expr as a bitset, and whether expr starts with *, as */n is unrestricted
+// like * when combining the day of the month and day of the week
+func (f field) parse(expr string) (uint64, bool, error) {
+	var set uint64
+	for _, part := range strings.Split(expr, ",") {
+		bits, err := f.parsePart(part)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %s %q: %w", f.name, expr, err)
+		}
+		set |= bits
+	}
+	return set, strings.HasPrefix(expr, "*"), nil
+}
+
+func (f field) parsePart(part string) (uint64, error) {
+	rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+	step := 1
+	if hasStep {
+		var err error
+		if step, err = strconv.Atoi(stepExpr); err != nil || step < 1 {
+			return 0, fmt.Errorf("step %q must be a positive integer", stepExpr)
+		}
+	}
+
+	var low, high int
+	switch lowExpr, highExpr, isRange := strings.Cut(rangeExpr, "-"); {
+	case rangeExpr == "*":
+		low, high = f.min, f.max
+	case isRange:
+		var err error
+		if low, err = f.value(lowExpr); err != nil {
+			return 0, err
+		}
+		if high, err = f.value(highExpr); err != nil {
+			return 0, err
+		}
+		if low > high {
+			return 0, fmt.Errorf("range %q is backwards", rangeExpr)
+		}
+	default:
+		var err error
+		if low, err = f.value(lowExpr); err != nil {
+			return 0, err
+		}
+		high = low
+		if hasStep {
+			// a/n means every nth value from a
+			high = f.max
+		}
+	}
+
+	var set uint64
+	for v := low; v <= high; v += step {
+		set |= 1 << v
+	}
+	return set, nil
+}
+
+func (f field) value(expr string) (int, error) {
+	if v, ok := f.names[strings.ToLower(expr)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", expr)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%d is outside of %d-%d", v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Location returns the location in which times are matched
+func (c *Cron) Location() *time.Location {
+	return c.location
+}
+
+// Next returns the first time after t that matches the expression, or the zero time if none does within five years,
+// for example if the expression is for the 30th of February.
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.In(c.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.location)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.location)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.location)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c *Cron) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// String returns the expression that was parsed
+func (c *Cron) String() string {
+	return c.expr
+}
@@ -0,0 +1,79 @@
+package schedule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/restatedev/sdk-go/schedule"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronNext(t *testing.T) {
+	// a Wednesday
+	from := time.Date(2024, time.January, 10, 13, 45, 30, 0, time.UTC)
+
+	cases := []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.January, 10, 13, 46, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.January, 10, 14, 0, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2024, time.January, 11, 2, 0, 0, 0, time.UTC)},
+		{"30 9 * * mon-fri", time.Date(2024, time.January, 11, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC)},
+		{"0 12 1,15 * *", time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)},
+		// either the day of the month or the day of the week matches
+		{"0 0 20 * fri", time.Date(2024, time.January, 12, 0, 0, 0, 0, time.UTC)},
+		// unless one of them starts with *, in which case both must match
+		{"0 0 */2 * fri", time.Date(2024, time.January, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * */7", time.Date(2024, time.September, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"5/20 8-10 * * *", time.Date(2024, time.January, 11, 8, 5, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, time.January, 10, 14, 0, 0, 0, time.UTC)},
+		{"0 0 30 feb *", time.Time{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			cron, err := schedule.ParseCron(c.expr, nil)
+			require.NoError(t, err)
+			require.Equal(t, c.next, cron.Next(from))
+		})
+	}
+}
+
+func TestCronLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	cron, err := schedule.ParseCron("0 2 * * *", berlin)
+	require.NoError(t, err)
+
+	next := cron.Next(time.Date(2024, time.January, 10, 13, 0, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2024, time.January, 11, 1, 0, 0, 0, time.UTC), next.UTC())
+
+	// 02:00 doesn't exist on the day clocks go forward
+	next = cron.Next(time.Date(2024, time.March, 30, 13, 0, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), next.UTC())
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"10-5 * * * *",
+		"*/0 * * * *",
+		"* * * foo *",
+	} {
+		_, err := schedule.ParseCron(expr, nil)
+		require.Error(t, err, expr)
+	}
+
+	_, err := schedule.ParseCron("@fortnightly", nil)
+	require.ErrorContains(t, err, `cron expression "@fortnightly" must have 5 fields`)
+}
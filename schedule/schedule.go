@@ -0,0 +1,246 @@
+// Package schedule runs durable recurring jobs on Restate. A job is a one-way call to a handler which is made at
+// every time matched by a cron expression. Each job is a key of a Virtual Object created with [NewScheduler], which
+// sends itself a one-way call for the next run of the job, so that the schedule survives restarts and deployments
+// without any timers being held by the service.
+//
+//	server.NewRestate().Bind(schedule.NewScheduler("Scheduler"))
+//
+//	if err := schedule.Start(ctx, "Scheduler", "nightly-report", schedule.Job{
+//		Cron:    "0 2 * * *",
+//		Service: "Reports",
+//		Handler: "generate",
+//	}); err != nil {
+//		// the cron expression or timezone of the job is invalid
+//		return err
+//	}
+package schedule
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+)
+
+// Job is a recurring one-way call
+type Job struct {
+	// Cron is the expression matching the times at which the call is made; see [ParseCron]
+	Cron string `json:"cron"`
+	// Timezone is the IANA name of the location in which Cron is matched, eg "Europe/Berlin"; defaults to UTC
+	Timezone string `json:"timezone,omitempty"`
+	// Service is the name of the service, Virtual Object or Workflow to call
+	Service string `json:"service"`
+	// Key is the key of the Virtual Object or Workflow to call, and is empty for services
+	Key string `json:"key,omitempty"`
+	// Handler is the name of the handler to call
+	Handler string `json:"handler"`
+	// Input is the JSON encoded input of the call
+	Input json.RawMessage `json:"input,omitempty"`
+}
+
+// ParseCron parses the cron expression of the job in its timezone
+func (j Job) ParseCron() (*Cron, error) {
+	location := time.UTC
+	if j.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(j.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", j.Timezone, err)
+		}
+	}
+	return ParseCron(j.Cron, location)
+}
+
+// Status describes a scheduled job
+type Status struct {
+	Job Job `json:"job"`
+	// Next is the time of the next run of the job
+	Next time.Time `json:"next"`
+	// Runs is the number of runs since the job was started
+	Runs uint64 `json:"runs"`
+}
+
+var (
+	jobKey = restate.NewStateKey[Job]("job")
+	// generationKey is incremented whenever a job is started, so that the pending tick of a replaced or stopped
+	// job can be recognised and ignored
+	generationKey = restate.NewStateKey[uint64]("generation").WithDefault(0)
+	nextKey       = restate.NewStateKey[time.Time]("next")
+	runsKey       = restate.NewStateKey[uint64]("runs").WithDefault(0)
+)
+
+// tick is the input of the one-way call which the scheduler sends itself for each run of a job
+type tick struct {
+	Generation uint64 `json:"generation"`
+}
+
+type scheduler struct {
+	name string
+}
+
+// NewScheduler creates a Virtual Object called name whose keys are scheduled jobs, with the following handlers:
+//   - Start takes a [Job], replacing any job already scheduled under the key, and returns the time of its first run
+//   - Stop deletes the job, cancelling its future runs
+//   - Get is a shared handler returning the [Status] of the job, or a terminal error with code 404 if there is none
+//   - Tick is internal: the scheduler sends it to itself to make each run of the job, and it should not be called
+func NewScheduler(name string) restate.ServiceDefinition {
+	s := scheduler{name}
+	return restate.NewObject(name).
+		Handler("Start", restate.NewObjectHandler(s.start)).
+		Handler("Stop", restate.NewObjectHandler(s.stop)).
+		Handler("Get", restate.NewObjectSharedHandler(s.get)).
+		Handler("Tick", restate.NewObjectHandler(s.tick))
+}
+
+// Start schedules job under the key name of the scheduler Virtual Object, replacing any job already scheduled under it.
+// As the job is started by a one-way call, its cron expression and timezone are parsed beforehand, and a terminal
+// error with code 400 is returned if they are invalid.
+func Start(ctx restate.Context, scheduler, name string, job Job) error {
+	if _, err := job.ParseCron(); err != nil {
+		return restate.TerminalError(err, 400)
+	}
+	return ctx.Object(scheduler, name, "Start").Send(job, 0)
+}
+
+// Stop stops the job scheduled under the key name of the scheduler Virtual Object
+func Stop(ctx restate.Context, scheduler, name string) error {
+	return ctx.Object(scheduler, name, "Stop").Send(restate.Void{}, 0)
+}
+
+func (s scheduler) start(ctx restate.ObjectContext, job Job) (time.Time, error) {
+	cron, err := job.ParseCron()
+	if err != nil {
+		return time.Time{}, restate.TerminalError(err, 400)
+	}
+
+	generation, err := generationKey.Get(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	generation++
+
+	if err := jobKey.Set(ctx, job); err != nil {
+		return time.Time{}, err
+	}
+	if err := generationKey.Set(ctx, generation); err != nil {
+		return time.Time{}, err
+	}
+	runsKey.Clear(ctx)
+
+	now, err := restate.RunAs(ctx, func(ctx restate.RunContext) (time.Time, error) {
+		return time.Now(), nil
+	}, restate.WithName("now"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return s.scheduleNext(ctx, cron, generation, now)
+}
+
+func (s scheduler) stop(ctx restate.ObjectContext, _ restate.Void) (restate.Void, error) {
+	// the generation is kept, so that the pending tick is ignored even if a job is started again
+	generation, err := generationKey.Get(ctx)
+	if err != nil {
+		return restate.Void{}, err
+	}
+	if err := generationKey.Set(ctx, generation+1); err != nil {
+		return restate.Void{}, err
+	}
+	clearJob(ctx)
+	return restate.Void{}, nil
+}
+
+func (s scheduler) get(ctx restate.ObjectSharedContext, _ restate.Void) (Status, error) {
+	job, err := jobKey.Get(ctx)
+	if errors.Is(err, restate.ErrKeyNotFound) {
+		return Status{}, restate.TerminalError(fmt.Errorf("no job is scheduled as %s", ctx.Key()), 404)
+	} else if err != nil {
+		return Status{}, err
+	}
+	next, err := nextKey.Get(ctx)
+	if err != nil {
+		return Status{}, err
+	}
+	runs, err := runsKey.Get(ctx)
+	if err != nil {
+		return Status{}, err
+	}
+	return Status{Job: job, Next: next, Runs: runs}, nil
+}
+
+func (s scheduler) tick(ctx restate.ObjectContext, t tick) (restate.Void, error) {
+	generation, err := generationKey.Get(ctx)
+	if err != nil {
+		return restate.Void{}, err
+	}
+	if generation != t.Generation {
+		// the job was replaced or stopped after this tick was sent
+		return restate.Void{}, nil
+	}
+
+	job, err := jobKey.Get(ctx)
+	if err != nil {
+		return restate.Void{}, err
+	}
+	cron, err := job.ParseCron()
+	if err != nil {
+		return restate.Void{}, restate.TerminalError(err, 400)
+	}
+	scheduled, err := nextKey.Get(ctx)
+	if err != nil {
+		return restate.Void{}, err
+	}
+
+	var target restate.SendClient
+	if job.Key == "" {
+		target = ctx.Service(job.Service, job.Handler)
+	} else {
+		target = ctx.Object(job.Service, job.Key, job.Handler)
+	}
+	if err := target.Send(job.Input, 0); err != nil {
+		return restate.Void{}, err
+	}
+
+	runs, err := runsKey.Get(ctx)
+	if err != nil {
+		return restate.Void{}, err
+	}
+	if err := runsKey.Set(ctx, runs+1); err != nil {
+		return restate.Void{}, err
+	}
+
+	now, err := restate.RunAs(ctx, func(ctx restate.RunContext) (time.Time, error) {
+		return time.Now(), nil
+	}, restate.WithName("now"))
+	if err != nil {
+		return restate.Void{}, err
+	}
+	// runs that were missed while the tick was delayed are skipped, rather than made all at once
+	if now.Before(scheduled) {
+		now = scheduled
+	}
+	_, err = s.scheduleNext(ctx, cron, generation, now)
+	return restate.Void{}, err
+}
+
+// scheduleNext sends the tick for the first run of the job after now
+func (s scheduler) scheduleNext(ctx restate.ObjectContext, cron *Cron, generation uint64, now time.Time) (time.Time, error) {
+	next := cron.Next(now)
+	if next.IsZero() {
+		clearJob(ctx)
+		return time.Time{}, restate.TerminalError(fmt.Errorf("cron expression %q has no more runs", cron), 400)
+	}
+	if err := nextKey.Set(ctx, next); err != nil {
+		return time.Time{}, err
+	}
+	if err := restate.SendAs[tick](ctx.Object(s.name, ctx.Key(), "Tick")).SendAt(tick{generation}, next); err != nil {
+		return time.Time{}, err
+	}
+	return next, nil
+}
+
+func clearJob(ctx restate.ObjectContext) {
+	jobKey.Clear(ctx)
+	nextKey.Clear(ctx)
+	runsKey.Clear(ctx)
+}
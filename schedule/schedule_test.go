@@ -0,0 +1,142 @@
+package schedule_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/restatedev/sdk-go/schedule"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+var job = schedule.Job{
+	Cron:    "0 2 * * *",
+	Service: "Reports",
+	Handler: "generate",
+	Input:   json.RawMessage(`{"format":"pdf"}`),
+}
+
+func oneWayCalls(journal []proto.Message) []*protocol.OneWayCallEntryMessage {
+	var calls []*protocol.OneWayCallEntryMessage
+	for _, entry := range journal {
+		if call, ok := entry.(*protocol.OneWayCallEntryMessage); ok {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func TestSchedulerStart(t *testing.T) {
+	handlers := schedule.NewScheduler("Scheduler").Handlers()
+	runtime := restatetest.Runtime{Key: "nightly"}
+
+	result, err := runtime.Invoke(context.Background(), handlers["Start"], restatetest.JSON(t, job))
+	require.NoError(t, err)
+	require.True(t, result.Completed())
+	require.NoError(t, result.TerminalError)
+
+	var next time.Time
+	require.NoError(t, json.Unmarshal(result.Output, &next))
+	require.True(t, next.After(time.Now()))
+	require.Equal(t, 2, next.UTC().Hour())
+	require.Equal(t, 0, next.Minute())
+
+	calls := oneWayCalls(result.Journal)
+	require.Len(t, calls, 1)
+	require.Equal(t, "Scheduler", calls[0].ServiceName)
+	require.Equal(t, "nightly", calls[0].Key)
+	require.Equal(t, "Tick", calls[0].HandlerName)
+	require.Equal(t, uint64(next.UnixMilli()), calls[0].InvokeTime)
+	require.JSONEq(t, `{"generation":1}`, string(calls[0].Parameter))
+	require.Equal(t, restatetest.JSON(t, 1), result.State["generation"])
+	require.NoError(t, runtime.CheckDeterminism(context.Background(), handlers["Start"], restatetest.JSON(t, job)))
+
+	t.Run("invalid cron expression", func(t *testing.T) {
+		invalid := job
+		invalid.Cron = "0 25 * * *"
+		result, err := runtime.Invoke(context.Background(), handlers["Start"], restatetest.JSON(t, invalid))
+		require.NoError(t, err)
+		require.EqualValues(t, 400, restate.ErrorCode(result.TerminalError))
+	})
+}
+
+func TestSchedulerTick(t *testing.T) {
+	handlers := schedule.NewScheduler("Scheduler").Handlers()
+	scheduled := time.Date(2024, time.January, 11, 2, 0, 0, 0, time.UTC)
+	runtime := restatetest.Runtime{
+		Key: "nightly",
+		State: map[string][]byte{
+			"job":        restatetest.JSON(t, job),
+			"generation": restatetest.JSON(t, 2),
+			"next":       restatetest.JSON(t, scheduled),
+			"runs":       restatetest.JSON(t, 4),
+		},
+	}
+
+	t.Run("runs the job", func(t *testing.T) {
+		result, err := runtime.Invoke(context.Background(), handlers["Tick"], []byte(`{"generation":2}`))
+		require.NoError(t, err)
+		require.True(t, result.Completed())
+		require.NoError(t, result.TerminalError)
+
+		calls := oneWayCalls(result.Journal)
+		require.Len(t, calls, 2)
+		require.Equal(t, "Reports", calls[0].ServiceName)
+		require.Equal(t, "generate", calls[0].HandlerName)
+		require.Zero(t, calls[0].InvokeTime)
+		require.JSONEq(t, string(job.Input), string(calls[0].Parameter))
+		require.Equal(t, "Tick", calls[1].HandlerName)
+		require.JSONEq(t, `{"generation":2}`, string(calls[1].Parameter))
+		require.Greater(t, calls[1].InvokeTime, uint64(time.Now().UnixMilli()))
+		require.Equal(t, restatetest.JSON(t, 5), result.State["runs"])
+		require.NoError(t, runtime.CheckDeterminism(context.Background(), handlers["Tick"], []byte(`{"generation":2}`)))
+	})
+
+	t.Run("ignores stale ticks", func(t *testing.T) {
+		result, err := runtime.Invoke(context.Background(), handlers["Tick"], []byte(`{"generation":1}`))
+		require.NoError(t, err)
+		require.True(t, result.Completed())
+		require.Empty(t, oneWayCalls(result.Journal))
+		require.Equal(t, restatetest.JSON(t, 4), result.State["runs"])
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		result, err := runtime.Invoke(context.Background(), handlers["Stop"], nil)
+		require.NoError(t, err)
+		require.True(t, result.Completed())
+		require.Equal(t, map[string][]byte{"generation": restatetest.JSON(t, 3)}, result.State)
+
+		stopped := restatetest.Runtime{Key: "nightly", State: result.State}
+		result, err = stopped.Invoke(context.Background(), handlers["Get"], nil)
+		require.NoError(t, err)
+		require.EqualValues(t, 404, restate.ErrorCode(result.TerminalError))
+	})
+}
+
+func TestStart(t *testing.T) {
+	handler := restate.NewServiceHandler(func(ctx restate.Context, job schedule.Job) (restate.Void, error) {
+		return restate.Void{}, schedule.Start(ctx, "Scheduler", "nightly", job)
+	})
+	restate.NewService("Reports").Handler("schedule", handler)
+
+	var runtime restatetest.Runtime
+	result := runtime.Complete(t, handler, restatetest.JSON(t, job))
+	require.NoError(t, result.TerminalError)
+	calls := oneWayCalls(result.Journal)
+	require.Len(t, calls, 1)
+	require.Equal(t, "Start", calls[0].HandlerName)
+
+	t.Run("invalid timezone", func(t *testing.T) {
+		invalid := job
+		invalid.Timezone = "Europe/Nowhere"
+		result := runtime.Complete(t, handler, restatetest.JSON(t, invalid))
+		require.EqualValues(t, 400, restate.ErrorCode(result.TerminalError))
+		require.ErrorContains(t, result.TerminalError, `invalid timezone "Europe/Nowhere"`)
+		require.Empty(t, oneWayCalls(result.Journal))
+	})
+}
@@ -0,0 +1,98 @@
+package restate
+
+import (
+	"time"
+
+	"github.com/restatedev/sdk-go/internal/futures"
+	"github.com/restatedev/sdk-go/internal/options"
+)
+
+// IndexedSelector is an extension of [Selector] which returns the position of each completed operation in the list
+// given to [SelectIndexed], along with the error it failed with, instead of the operation itself. Like [Selector],
+// the order of completion is stored in the journal, so that it is the same on replay.
+type IndexedSelector struct {
+	inner   Selector
+	indexes map[uint32]int
+}
+
+// SelectIndexed is an alternative to Context.Select which returns an [IndexedSelector]
+func SelectIndexed(ctx Context, futs ...Selectable) *IndexedSelector {
+	indexes := make(map[uint32]int, len(futs))
+	for i, fut := range futs {
+		indexes[futures.EntryIndex(fut)] = i
+	}
+	return &IndexedSelector{ctx.Select(futs...), indexes}
+}
+
+// Remaining returns whether there are still operations that haven't been returned by Select
+func (s *IndexedSelector) Remaining() bool {
+	return s.inner.Remaining()
+}
+
+// Select blocks on the next completed operation, returning its position and, if it failed, its error. The result
+// of a successful operation can then be read from it without blocking. If there are no operations left, the
+// index is -1.
+func (s *IndexedSelector) Select() (index int, err error) {
+	fut := s.inner.Select()
+	if fut == nil {
+		return -1, nil
+	}
+	return s.indexes[futures.EntryIndex(fut)], futures.Failure(fut)
+}
+
+// AwaitTimeout blocks until fut completes or d has elapsed, returning [ErrTimeout] in the latter case. The timer is
+// durable, so on replay the same outcome is observed. If fut completed, its result can then be read from it without
+// blocking.
+func AwaitTimeout(ctx Context, fut Selectable, d time.Duration, opts ...options.SleepOption) error {
+	// the entry of fut may be written lazily, eg for a promise; write it before the timer's, so that fut is
+	// preferred if both have already completed
+	futures.EntryIndex(fut)
+	index, err := SelectIndexed(ctx, fut, ctx.After(d, opts...)).Select()
+	if index == 1 {
+		if err != nil {
+			// the timer was cancelled, along with the invocation
+			return err
+		}
+		return ErrTimeout
+	}
+	return err
+}
+
+// FirstOf blocks until the first of futs completes, returning its position and, if it failed, its error. If futs is
+// empty, [ErrNoFutures] is returned with an index of -1.
+func FirstOf(ctx Context, futs ...Selectable) (index int, err error) {
+	if len(futs) == 0 {
+		return -1, ErrNoFutures
+	}
+	return SelectIndexed(ctx, futs...).Select()
+}
+
+// AnyOf blocks until the first of futs completes successfully, returning its position. If all of them fail, the
+// error of the last to fail is returned, with an index of -1. If futs is empty, [ErrNoFutures] is returned.
+func AnyOf(ctx Context, futs ...Selectable) (index int, err error) {
+	if len(futs) == 0 {
+		return -1, ErrNoFutures
+	}
+	selector := SelectIndexed(ctx, futs...)
+	for selector.Remaining() {
+		index, err = selector.Select()
+		if err == nil {
+			return index, nil
+		}
+	}
+	return -1, err
+}
+
+// All blocks until all of futs complete successfully, after which their results can be read without blocking.
+// It returns as soon as any of them fails, with its position and error; otherwise the index is -1, as it is when
+// futs is empty.
+func All(ctx Context, futs ...Selectable) (index int, err error) {
+	selector := SelectIndexed(ctx, futs...)
+	for selector.Remaining() {
+		index, err = selector.Select()
+		if err != nil {
+			return index, err
+		}
+	}
+	return -1, nil
+}
@@ -0,0 +1,111 @@
+package restate_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAwaitTimeout(t *testing.T) {
+	handler := restate.NewWorkflowHandler(func(ctx restate.WorkflowContext, _ restate.Void) (string, error) {
+		promise := restate.PromiseAs[string](ctx, "approval")
+		if err := restate.AwaitTimeout(ctx, promise, time.Minute); err != nil {
+			return "", err
+		}
+		return promise.Result()
+	})
+	restate.NewWorkflow("Approval").Handler("run", handler)
+
+	t.Run("completed", func(t *testing.T) {
+		// the sleep completes immediately too, but the promise entry is written first
+		runtime := restatetest.Runtime{
+			Key: "wf-1",
+			Promises: map[string]*restatetest.Completion{
+				"approval": restatetest.Value(restatetest.JSON(t, "approved")),
+			},
+		}
+		result := runtime.Complete(t, handler, nil)
+		require.NoError(t, result.TerminalError)
+		require.Equal(t, restatetest.JSON(t, "approved"), result.Output)
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		runtime := restatetest.Runtime{Key: "wf-1"}
+		result, err := runtime.Invoke(context.Background(), handler, nil)
+		require.NoError(t, err)
+		require.True(t, result.Suspended)
+		require.IsType(t, &protocol.GetPromiseEntryMessage{}, result.Journal[0])
+		require.IsType(t, &protocol.SleepEntryMessage{}, result.Journal[1])
+		require.Equal(t, []uint32{1, 2}, result.SuspendedOn)
+	})
+}
+
+func TestSelectHelpers(t *testing.T) {
+	handler := restate.NewServiceHandler(func(ctx restate.Context, helper string) (string, error) {
+		failing, err := restate.CallAs[string](ctx.Service("Greeter", "fail")).RequestFuture(nil)
+		if err != nil {
+			return "", err
+		}
+		greeting, err := restate.CallAs[string](ctx.Service("Greeter", "greet")).RequestFuture(nil)
+		if err != nil {
+			return "", err
+		}
+
+		var index int
+		switch helper {
+		case "first":
+			index, err = restate.FirstOf(ctx, failing, greeting)
+		case "any":
+			index, err = restate.AnyOf(ctx, failing, greeting)
+		case "all":
+			index, err = restate.All(ctx, failing, greeting)
+		}
+		return fmt.Sprintf("%d %v", index, err), nil
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	runtime := restatetest.Runtime{
+		Call: func(target restatetest.Target, input []byte) *restatetest.Completion {
+			if target.Handler == "fail" {
+				return restatetest.Failure(restate.TerminalError(fmt.Errorf("failed"), 500))
+			}
+			return restatetest.Value(restatetest.JSON(t, "hello"))
+		},
+	}
+
+	// both calls complete immediately, in which case the earliest is selected first
+	for helper, expected := range map[string]string{
+		"first": "0 [500] failed",
+		"any":   "1 <nil>",
+		"all":   "0 [500] failed",
+	} {
+		t.Run(helper, func(t *testing.T) {
+			result := runtime.Complete(t, handler, restatetest.JSON(t, helper))
+			require.NoError(t, result.TerminalError)
+			require.Equal(t, restatetest.JSON(t, expected), result.Output)
+		})
+	}
+}
+
+func TestSelectHelpersWithoutFutures(t *testing.T) {
+	handler := restate.NewServiceHandler(func(ctx restate.Context, _ restate.Void) ([]string, error) {
+		var results []string
+		for _, helper := range []func(restate.Context, ...restate.Selectable) (int, error){restate.FirstOf, restate.AnyOf, restate.All} {
+			index, err := helper(ctx)
+			results = append(results, fmt.Sprintf("%d %v", index, err))
+		}
+		return results, nil
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	var runtime restatetest.Runtime
+	result := runtime.Complete(t, handler, nil)
+	require.NoError(t, result.TerminalError)
+	require.Equal(t, restatetest.JSON(t, []string{"-1 [400] no futures to wait for", "-1 [400] no futures to wait for", "-1 <nil>"}), result.Output)
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/restatedev/sdk-go/internal/state"
+)
+
+// invocations tracks the state machines of in-flight invocations, so that they can be drained on shutdown
+type invocations struct {
+	mutex    sync.Mutex
+	machines map[*state.Machine]struct{}
+	draining bool
+	wg       sync.WaitGroup
+}
+
+// add tracks machine until done is called, returning false if the server is draining and so the invocation
+// should be rejected
+func (i *invocations) add(machine *state.Machine) bool {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if i.draining {
+		return false
+	}
+	if i.machines == nil {
+		i.machines = make(map[*state.Machine]struct{})
+	}
+	i.machines[machine] = struct{}{}
+	i.wg.Add(1)
+	return true
+}
+
+func (i *invocations) done(machine *state.Machine) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	delete(i.machines, machine)
+	i.wg.Done()
+}
+
+// suspend asks all in-flight invocations to suspend at their next blocking operation
+func (i *invocations) suspend() int {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	for machine := range i.machines {
+		machine.Suspend()
+	}
+	return len(i.machines)
+}
+
+// drain stops accepting invocations, then waits up to timeout for the in-flight invocations to complete, and
+// otherwise returns false
+func (i *invocations) drain(timeout time.Duration) bool {
+	i.mutex.Lock()
+	i.draining = true
+	i.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		i.wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
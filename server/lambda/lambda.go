@@ -0,0 +1,180 @@
+// Package lambda serves a [server.Restate] on AWS Lambda. Restate invokes the function with an event in the format
+// of an API Gateway proxy request, whose body is the framed service protocol, and the function responds with all of
+// the messages of the invocation at once, as Lambda does not support bidirectional streaming.
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/restatedev/sdk-go/server"
+)
+
+// Handler translates Lambda events into requests to the http.Handler of a [server.Restate]
+type Handler struct {
+	handler http.HandlerFunc
+}
+
+// New creates a Handler for the services bound to r, which is switched to request-response mode with
+// .Bidirectional(false).
+func New(r *server.Restate) (*Handler, error) {
+	handler, err := r.Bidirectional(false).Handler()
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{handler}, nil
+}
+
+// Start creates a Handler for r and starts the Lambda runtime with it. Like [lambda.Start], it does not return.
+func Start(r *server.Restate) error {
+	handler, err := New(r)
+	if err != nil {
+		return err
+	}
+	lambda.Start(handler.Invoke)
+	return nil
+}
+
+// Invoke handles an event in the format of an API Gateway proxy request, which is how Restate invokes Lambda
+// functions directly
+func (h *Handler) Invoke(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	body, err := decodeBody(event.Body, event.IsBase64Encoded)
+	if err != nil {
+		return events.APIGatewayProxyResponse{}, err
+	}
+
+	headers := make(http.Header, len(event.Headers)+len(event.MultiValueHeaders))
+	for key, values := range event.MultiValueHeaders {
+		for _, value := range values {
+			headers.Add(key, value)
+		}
+	}
+	for key, value := range event.Headers {
+		if headers.Get(key) == "" {
+			headers.Set(key, value)
+		}
+	}
+
+	method := event.HTTPMethod
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	response := h.serve(ctx, method, event.Path, headers, body)
+
+	return events.APIGatewayProxyResponse{
+		StatusCode:      response.status,
+		Headers:         singleValued(response.header),
+		Body:            base64.StdEncoding.EncodeToString(response.body.Bytes()),
+		IsBase64Encoded: true,
+	}, nil
+}
+
+// InvokeFunctionURL handles an event from a Lambda function URL, for deployments where Restate reaches the
+// function over HTTP
+func (h *Handler) InvokeFunctionURL(ctx context.Context, event events.LambdaFunctionURLRequest) (events.LambdaFunctionURLResponse, error) {
+	body, err := decodeBody(event.Body, event.IsBase64Encoded)
+	if err != nil {
+		return events.LambdaFunctionURLResponse{}, err
+	}
+
+	headers := make(http.Header, len(event.Headers))
+	for key, value := range event.Headers {
+		headers.Set(key, value)
+	}
+
+	method := event.RequestContext.HTTP.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	response := h.serve(ctx, method, event.RawPath, headers, body)
+
+	return events.LambdaFunctionURLResponse{
+		StatusCode:      response.status,
+		Headers:         singleValued(response.header),
+		Body:            base64.StdEncoding.EncodeToString(response.body.Bytes()),
+		IsBase64Encoded: true,
+	}, nil
+}
+
+func decodeBody(body string, isBase64Encoded bool) ([]byte, error) {
+	if !isBase64Encoded {
+		return []byte(body), nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 event body: %w", err)
+	}
+	return decoded, nil
+}
+
+func (h *Handler) serve(ctx context.Context, method, path string, headers http.Header, body []byte) *responseWriter {
+	request := (&http.Request{
+		Method:        method,
+		URL:           &url.URL{Path: path},
+		RequestURI:    path,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          http.NoBody,
+		ContentLength: int64(len(body)),
+	}).WithContext(ctx)
+	if len(body) > 0 {
+		request.Body = readCloser{bytes.NewReader(body)}
+	}
+
+	response := &responseWriter{header: make(http.Header)}
+	h.handler(response, request)
+	if response.status == 0 {
+		response.status = http.StatusOK
+	}
+	return response
+}
+
+type readCloser struct {
+	*bytes.Reader
+}
+
+func (readCloser) Close() error { return nil }
+
+// responseWriter buffers the response, which Lambda returns in full once the invocation has ended
+type responseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+var _ http.ResponseWriter = &responseWriter{}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *responseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(data)
+}
+
+func singleValued(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key := range header {
+		headers[key] = header.Get(key)
+	}
+	return headers
+}
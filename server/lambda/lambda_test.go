@@ -0,0 +1,132 @@
+package lambda_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/internal/wire"
+	"github.com/restatedev/sdk-go/server"
+	"github.com/restatedev/sdk-go/server/lambda"
+	"github.com/stretchr/testify/require"
+)
+
+func newHandler(t *testing.T) *lambda.Handler {
+	greeter := restate.NewService("Greeter").
+		Handler("greet", restate.NewServiceHandler(func(ctx restate.Context, name string) (string, error) {
+			return "hello " + name, nil
+		})).
+		Handler("greetLater", restate.NewServiceHandler(func(ctx restate.Context, name string) (string, error) {
+			if err := ctx.Sleep(time.Minute); err != nil {
+				return "", err
+			}
+			return "hello " + name, nil
+		}))
+
+	handler, err := lambda.New(server.NewRestate().Bind(greeter))
+	require.NoError(t, err)
+	return handler
+}
+
+func readFixture(t *testing.T, name string, event any) {
+	t.Helper()
+	bytes, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, event))
+}
+
+type reader struct {
+	*bytes.Reader
+}
+
+func (reader) Write([]byte) (int, error) { panic("unexpected write") }
+
+func readMessages(t *testing.T, body string) []wire.Message {
+	t.Helper()
+	decoded, err := base64.StdEncoding.DecodeString(body)
+	require.NoError(t, err)
+
+	protocol := wire.NewProtocol(reader{bytes.NewReader(decoded)})
+	var messages []wire.Message
+	for {
+		msg, _, err := protocol.Read()
+		if err != nil {
+			return messages
+		}
+		messages = append(messages, msg)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	var event events.APIGatewayProxyRequest
+	readFixture(t, "discover.json", &event)
+
+	response, err := newHandler(t).Invoke(context.Background(), event)
+	require.NoError(t, err)
+	require.Equal(t, 200, response.StatusCode)
+	require.Equal(t, "application/vnd.restate.endpointmanifest.v1+json", response.Headers["Content-Type"])
+
+	body, err := base64.StdEncoding.DecodeString(response.Body)
+	require.NoError(t, err)
+	var manifest struct {
+		ProtocolMode string `json:"protocolMode"`
+	}
+	require.NoError(t, json.Unmarshal(body, &manifest))
+	require.Equal(t, "REQUEST_RESPONSE", manifest.ProtocolMode)
+}
+
+func TestInvoke(t *testing.T) {
+	var event events.APIGatewayProxyRequest
+	readFixture(t, "invoke_greet.json", &event)
+
+	response, err := newHandler(t).Invoke(context.Background(), event)
+	require.NoError(t, err)
+	require.Equal(t, 200, response.StatusCode)
+	require.Equal(t, "application/vnd.restate.invocation.v2", response.Headers["Content-Type"])
+	require.True(t, response.IsBase64Encoded)
+
+	messages := readMessages(t, response.Body)
+	require.Len(t, messages, 2)
+	output, ok := messages[0].(*wire.OutputEntryMessage)
+	require.True(t, ok)
+	require.Equal(t, []byte(`"hello bob"`), output.GetValue())
+	require.IsType(t, &wire.EndMessage{}, messages[1])
+}
+
+func TestInvokeSuspends(t *testing.T) {
+	var event events.APIGatewayProxyRequest
+	readFixture(t, "invoke_greetLater.json", &event)
+
+	response, err := newHandler(t).Invoke(context.Background(), event)
+	require.NoError(t, err)
+	require.Equal(t, 200, response.StatusCode)
+
+	messages := readMessages(t, response.Body)
+	require.Len(t, messages, 2)
+	require.IsType(t, &wire.SleepEntryMessage{}, messages[0])
+	suspension, ok := messages[1].(*wire.SuspensionMessage)
+	require.True(t, ok)
+	require.Equal(t, []uint32{1}, suspension.EntryIndexes)
+}
+
+func TestInvokeFunctionURL(t *testing.T) {
+	var event events.LambdaFunctionURLRequest
+	readFixture(t, "function_url_greet.json", &event)
+
+	response, err := newHandler(t).InvokeFunctionURL(context.Background(), event)
+	require.NoError(t, err)
+	require.Equal(t, 200, response.StatusCode)
+
+	messages := readMessages(t, response.Body)
+	require.Len(t, messages, 2)
+	output, ok := messages[0].(*wire.OutputEntryMessage)
+	require.True(t, ok)
+	require.Equal(t, []byte(`"hello bob"`), output.GetValue())
+}
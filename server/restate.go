@@ -2,13 +2,16 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
 	"runtime/debug"
+	"sort"
 	"strings"
+	"time"
 
 	restate "github.com/restatedev/sdk-go"
 	"github.com/restatedev/sdk-go/generated/proto/discovery"
@@ -17,13 +20,16 @@ import (
 	"github.com/restatedev/sdk-go/internal/identity"
 	"github.com/restatedev/sdk-go/internal/log"
 	"github.com/restatedev/sdk-go/internal/state"
+	"github.com/restatedev/sdk-go/metrics"
+	"github.com/restatedev/sdk-go/tracing"
 	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 const minServiceProtocolVersion protocol.ServiceProtocolVersion = protocol.ServiceProtocolVersion_V1
-const maxServiceProtocolVersion protocol.ServiceProtocolVersion = protocol.ServiceProtocolVersion_V1
+const maxServiceProtocolVersion protocol.ServiceProtocolVersion = protocol.ServiceProtocolVersion_V3
 const minServiceDiscoveryProtocolVersion discovery.ServiceDiscoveryProtocolVersion = discovery.ServiceDiscoveryProtocolVersion_V1
-const maxServiceDiscoveryProtocolVersion discovery.ServiceDiscoveryProtocolVersion = discovery.ServiceDiscoveryProtocolVersion_V1
+const maxServiceDiscoveryProtocolVersion discovery.ServiceDiscoveryProtocolVersion = discovery.ServiceDiscoveryProtocolVersion_V2
 
 var xRestateServer = `restate-sdk-go/unknown`
 
@@ -49,6 +55,16 @@ type Restate struct {
 	keyIDs         []string
 	keySet         identity.KeySetV1
 	protocolMode   internal.ProtocolMode
+	tracer         tracing.Tracer
+	metrics        metrics.Recorder
+	middleware     []restate.Middleware
+	drainTimeout   time.Duration
+	invocations    invocations
+	tlsConfig      *tls.Config
+	certFile       string
+	keyFile        string
+	http1          bool
+	lazyState      bool
 }
 
 // NewRestate creates a new instance of Restate server
@@ -60,6 +76,7 @@ func NewRestate() *Restate {
 		dropReplayLogs: true,
 		definitions:    make(map[string]restate.ServiceDefinition),
 		protocolMode:   internal.ProtocolMode_BIDI_STREAM,
+		drainTimeout:   10 * time.Second,
 	}
 }
 
@@ -74,6 +91,70 @@ func (r *Restate) WithLogger(h slog.Handler, dropReplayLogs bool) *Restate {
 	return r
 }
 
+// WithTracer enables tracing of invocations, for example with OpenTelemetry using
+// [github.com/restatedev/sdk-go/tracing/opentelemetry.NewTracer]. A span is started for each attempt, parented on
+// the W3C trace context sent by Restate, with child spans for each Run and sleep. Entries replayed from the journal
+// are recorded as events on the attempt span rather than emitted again. The trace context is propagated to outgoing
+// calls.
+func (r *Restate) WithTracer(tracer tracing.Tracer) *Restate {
+	r.tracer = tracer
+	return r
+}
+
+// WithMetrics records a summary of every invocation attempt handled by this server with the provided recorder,
+// for example one created with [metrics.NewPrometheus] or [metrics.NewExpvar].
+func (r *Restate) WithMetrics(recorder metrics.Recorder) *Restate {
+	r.metrics = recorder
+	return r
+}
+
+// WithMiddleware wraps every handler bound to this server with the provided middleware, which runs before
+// any middleware of the service or handler. The first middleware provided is the outermost.
+func (r *Restate) WithMiddleware(middleware ...restate.Middleware) *Restate {
+	r.middleware = append(r.middleware, middleware...)
+	return r
+}
+
+// WithDrainTimeout sets how long Start waits on shutdown for in-flight invocations to complete, defaulting to 10s.
+// Invocations which are still running after the timeout are asked to suspend at their next blocking operation,
+// and those which have not done so after a further timeout (eg because they are in a long Run) are cancelled.
+func (r *Restate) WithDrainTimeout(timeout time.Duration) *Restate {
+	r.drainTimeout = timeout
+	return r
+}
+
+// WithTLS makes Start and Serve accept TLS connections using the provided config, negotiating HTTP2 with ALPN.
+// The config may use GetCertificate to rotate certificates.
+func (r *Restate) WithTLS(config *tls.Config) *Restate {
+	r.tlsConfig = config
+	return r
+}
+
+// WithTLSCertFiles makes Start and Serve accept TLS connections using the certificate and key in the provided
+// PEM files. The files are reloaded whenever they are modified, so that rotated certificates are used for new
+// connections without a restart.
+func (r *Restate) WithTLSCertFiles(certFile, keyFile string) *Restate {
+	r.certFile = certFile
+	r.keyFile = keyFile
+	return r
+}
+
+// WithHTTP1 makes Start and Serve accept HTTP1.1 requests in addition to HTTP2, for deployments behind proxies
+// which cannot forward HTTP2. Without TLS, HTTP2 is accepted either with prior knowledge or with an h2c upgrade.
+// In bidirectional mode, HTTP1.1 requests are served in full duplex, which requires that no proxy buffers them;
+// otherwise, use .Bidirectional(false).
+func (r *Restate) WithHTTP1(http1 bool) *Restate {
+	r.http1 = http1
+	return r
+}
+
+// WithLazyState makes Restate send the state of Virtual Objects and Workflows lazily, when it is read, rather than
+// with each invocation. It can be overridden for each Virtual Object or Workflow with [restate.WithLazyState].
+func (r *Restate) WithLazyState(lazy bool) *Restate {
+	r.lazyState = lazy
+	return r
+}
+
 // WithIdentityV1 attaches v1 request identity public keys to this server. All incoming requests will be validated
 // against one of these keys.
 func (r *Restate) WithIdentityV1(keys ...string) *Restate {
@@ -95,7 +176,8 @@ func (r *Restate) Bidirectional(bidi bool) *Restate {
 	return r
 }
 
-// Bind attaches a Service Definition (a Service or Virtual Object) to this server
+// Bind attaches a Service Definition (a Service, Virtual Object or Workflow) to this server. A Workflow must have
+// exactly one handler created with [restate.NewWorkflowHandler].
 func (r *Restate) Bind(definition restate.ServiceDefinition) *Restate {
 	if _, ok := r.definitions[definition.Name()]; ok {
 		// panic because this is a programming error
@@ -103,16 +185,37 @@ func (r *Restate) Bind(definition restate.ServiceDefinition) *Restate {
 		panic("service definition with the same name exists")
 	}
 
+	if definition.Type() == internal.ServiceType_WORKFLOW {
+		// as above, a workflow without a single run handler is a programming error
+		var runs []string
+		for name, handler := range definition.Handlers() {
+			if ty := handler.HandlerType(); ty != nil && *ty == internal.ServiceHandlerType_WORKFLOW {
+				runs = append(runs, name)
+			}
+		}
+		if len(runs) != 1 {
+			sort.Strings(runs)
+			panic(fmt.Sprintf("workflow %s must have exactly one run handler, but has %d: %v", definition.Name(), len(runs), runs))
+		}
+	}
+
 	r.definitions[definition.Name()] = definition
 
 	return r
 }
 
-func (r *Restate) discover() (resource *internal.Endpoint, err error) {
+// lazyStateDefinition is implemented by Virtual Objects and Workflows, which return whether their state should be
+// sent lazily, or nil to use the default of the server. It isn't part of [restate.ServiceDefinition], so that other
+// implementations of it don't need to provide it.
+type lazyStateDefinition interface {
+	LazyState() *bool
+}
+
+func (r *Restate) discover(version discovery.ServiceDiscoveryProtocolVersion) (resource *internal.Endpoint, err error) {
 	resource = &internal.Endpoint{
 		ProtocolMode:       r.protocolMode,
 		MinProtocolVersion: int32(minServiceProtocolVersion),
-		MaxProtocolVersion: int32(maxServiceDiscoveryProtocolVersion),
+		MaxProtocolVersion: int32(maxServiceProtocolVersion),
 		Services:           make([]internal.Service, 0, len(r.definitions)),
 	}
 
@@ -122,6 +225,20 @@ func (r *Restate) discover() (resource *internal.Endpoint, err error) {
 			Ty:       definition.Type(),
 			Handlers: make([]internal.Handler, 0, len(definition.Handlers())),
 		}
+		if definition.Type() != internal.ServiceType_SERVICE {
+			var lazy *bool
+			if definition, ok := definition.(lazyStateDefinition); ok {
+				lazy = definition.LazyState()
+			}
+			if lazy == nil && r.lazyState {
+				lazy = &r.lazyState
+			}
+			if version >= discovery.ServiceDiscoveryProtocolVersion_V2 {
+				service.EnableLazyState = lazy
+			} else if lazy != nil && *lazy {
+				r.systemLog.Warn("Restate does not support lazy state, so state will be sent eagerly", "service", name)
+			}
+		}
 
 		for name, handler := range definition.Handlers() {
 			service.Handlers = append(service.Handlers, internal.Handler{
@@ -156,7 +273,7 @@ func (r *Restate) discoverHandler(writer http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	response, err := r.discover()
+	response, err := r.discover(serviceDiscoveryProtocolVersion)
 	if err != nil {
 		writer.WriteHeader(http.StatusInternalServerError)
 		writer.Write([]byte(err.Error()))
@@ -193,8 +310,11 @@ func selectSupportedServiceDiscoveryProtocolVersion(accept string) discovery.Ser
 }
 
 func parseServiceDiscoveryProtocolVersion(versionString string) discovery.ServiceDiscoveryProtocolVersion {
-	if strings.TrimSpace(versionString) == "application/vnd.restate.endpointmanifest.v1+json" {
+	switch strings.TrimSpace(versionString) {
+	case "application/vnd.restate.endpointmanifest.v1+json":
 		return discovery.ServiceDiscoveryProtocolVersion_V1
+	case "application/vnd.restate.endpointmanifest.v2+json":
+		return discovery.ServiceDiscoveryProtocolVersion_V2
 	}
 
 	return discovery.ServiceDiscoveryProtocolVersion_SERVICE_DISCOVERY_PROTOCOL_VERSION_UNSPECIFIED
@@ -208,13 +328,20 @@ func serviceDiscoveryProtocolVersionToHeaderValue(serviceDiscoveryProtocolVersio
 	switch serviceDiscoveryProtocolVersion {
 	case discovery.ServiceDiscoveryProtocolVersion_V1:
 		return "application/vnd.restate.endpointmanifest.v1+json"
+	case discovery.ServiceDiscoveryProtocolVersion_V2:
+		return "application/vnd.restate.endpointmanifest.v2+json"
 	}
 	panic(fmt.Sprintf("unexpected service discovery protocol version %d", serviceDiscoveryProtocolVersion))
 }
 
 func parseServiceProtocolVersion(versionString string) protocol.ServiceProtocolVersion {
-	if strings.TrimSpace(versionString) == "application/vnd.restate.invocation.v1" {
+	switch strings.TrimSpace(versionString) {
+	case "application/vnd.restate.invocation.v1":
 		return protocol.ServiceProtocolVersion_V1
+	case "application/vnd.restate.invocation.v2":
+		return protocol.ServiceProtocolVersion_V2
+	case "application/vnd.restate.invocation.v3":
+		return protocol.ServiceProtocolVersion_V3
 	}
 
 	return protocol.ServiceProtocolVersion_SERVICE_PROTOCOL_VERSION_UNSPECIFIED
@@ -228,6 +355,10 @@ func serviceProtocolVersionToHeaderValue(serviceProtocolVersion protocol.Service
 	switch serviceProtocolVersion {
 	case protocol.ServiceProtocolVersion_V1:
 		return "application/vnd.restate.invocation.v1"
+	case protocol.ServiceProtocolVersion_V2:
+		return "application/vnd.restate.invocation.v2"
+	case protocol.ServiceProtocolVersion_V3:
+		return "application/vnd.restate.invocation.v3"
 	}
 	panic(fmt.Sprintf("unexpected service protocol version %d", serviceProtocolVersion))
 }
@@ -256,17 +387,48 @@ func (r *Restate) callHandler(serviceProtocolVersion protocol.ServiceProtocolVer
 		writer.WriteHeader(http.StatusNotFound)
 	}
 
-	writer.WriteHeader(200)
-
 	conn := newConnection(writer, request)
 
 	defer conn.Close()
 
-	machine := state.NewMachine(handler, conn, request.Header)
+	machine := state.NewMachine(handler, conn, serviceProtocolVersion, request.Header)
+	machine.Use(r.middleware...)
 
-	if err := machine.Start(request.Context(), r.dropReplayLogs, r.logHandler); err != nil {
+	if !r.invocations.add(machine) {
+		logger.WarnContext(request.Context(), "Rejecting invocation as the server is shutting down")
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	defer r.invocations.done(machine)
+
+	writer.WriteHeader(200)
+
+	ctx := request.Context()
+	if r.tracer != nil {
+		var span tracing.Span
+		ctx, span = r.tracer.StartAttempt(ctx, service, method, request.Header)
+		ctx = tracing.ContextWithSpan(ctx, span)
+		defer func() { span.End(time.Now()) }()
+	}
+
+	start := time.Now()
+	err := machine.Start(ctx, r.dropReplayLogs, r.logHandler)
+	if err != nil {
 		r.systemLog.LogAttrs(request.Context(), slog.LevelError, "Failed to handle invocation", log.Error(err))
 	}
+
+	if r.metrics != nil {
+		attempt := machine.Metrics()
+		attempt.Service = service
+		attempt.Handler = method
+		attempt.Duration = time.Since(start)
+		if err != nil && (attempt.Outcome == "" || attempt.Outcome == metrics.Success) {
+			// the protocol could not be driven, eg the output could not be written
+			attempt.Outcome = metrics.ProtocolViolation
+			attempt.ErrorCode = restate.ErrorCode(err)
+		}
+		r.metrics.RecordAttempt(attempt)
+	}
 }
 
 func (r *Restate) handler(writer http.ResponseWriter, request *http.Request) {
@@ -356,31 +518,141 @@ func (r *Restate) Handler() (http.HandlerFunc, error) {
 	return http.HandlerFunc(r.handler), nil
 }
 
-// Start starts a HTTP2 server serving the bound services
+// Start listens on address and serves the bound services as with Serve. The address is a TCP address, eg ":9080",
+// or the path of a Unix socket prefixed with "unix:", eg "unix:/var/run/restate.sock".
 func (r *Restate) Start(ctx context.Context, address string) error {
+	network := "tcp"
+	if path, ok := strings.CutPrefix(address, "unix:"); ok {
+		network, address = "unix", path
+	}
+
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on address %s: %w", address, err)
+	}
+
+	return r.Serve(ctx, listener)
+}
+
+// Serve serves the bound services on listener, which is closed when Serve returns. By default, cleartext HTTP2
+// with prior knowledge is served; see .WithTLS(), .WithTLSCertFiles() and .WithHTTP1() for other options.
+// When ctx is done, the server stops accepting connections and new invocations, and drains in-flight
+// invocations as configured with .WithDrainTimeout(), before returning nil.
+func (r *Restate) Serve(ctx context.Context, listener net.Listener) error {
+	defer listener.Close()
+
 	handler, err := r.Handler()
 	if err != nil {
 		return err
 	}
 
-	listener, err := net.Listen("tcp", address)
+	tlsConfig, err := r.serverTLSConfig()
 	if err != nil {
-		return fmt.Errorf("failed to listen on address %s: %w", address, err)
+		return err
 	}
 
-	var h2server http2.Server
+	// in-flight invocations must outlive ctx while draining, and are only cancelled once draining has failed
+	serveCtx, cancelServe := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelServe()
 
-	opts := &http2.ServeConnOpts{
-		Context: ctx,
-		Handler: handler,
+	server := &http.Server{
+		Handler:     r.requireHTTP2(handler),
+		TLSConfig:   tlsConfig,
+		BaseContext: func(net.Listener) context.Context { return serveCtx },
+	}
+	// also makes the http2 server send GOAWAY to its connections when server is shut down
+	h2server := &http2.Server{}
+	if err := http2.ConfigureServer(server, h2server); err != nil {
+		return fmt.Errorf("failed to configure http2 server: %w", err)
 	}
 
-	for {
-		con, err := listener.Accept()
-		if err != nil {
-			return fmt.Errorf("failed to accept connection: %w", err)
+	if tlsConfig != nil {
+		if !r.http1 {
+			server.TLSConfig.NextProtos = []string{http2.NextProtoTLS}
+		}
+		listener = tls.NewListener(listener, server.TLSConfig)
+	} else {
+		server.Handler = h2c.NewHandler(server.Handler, h2server)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("failed to serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	r.shutdown(server)
+
+	cancelServe()
+	server.Close()
+	<-serveErr
+
+	return nil
+}
+
+func (r *Restate) serverTLSConfig() (*tls.Config, error) {
+	if r.certFile == "" && r.keyFile == "" {
+		if r.tlsConfig == nil {
+			return nil, nil
+		}
+		return r.tlsConfig.Clone(), nil
+	}
+
+	reloader, err := newCertReloader(r.certFile, r.keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{}
+	if r.tlsConfig != nil {
+		config = r.tlsConfig.Clone()
+	}
+	config.GetCertificate = reloader.GetCertificate
+	return config, nil
+}
+
+// requireHTTP2 rejects HTTP1.1 requests unless they were enabled with .WithHTTP1()
+func (r *Restate) requireHTTP2(handler http.Handler) http.Handler {
+	if r.http1 {
+		return handler
+	}
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if !request.ProtoAtLeast(2, 0) {
+			writer.WriteHeader(http.StatusHTTPVersionNotSupported)
+			writer.Write([]byte("HTTP2 is required, or the server must be created with .WithHTTP1(true)"))
+			return
 		}
+		handler.ServeHTTP(writer, request)
+	})
+}
 
-		go h2server.ServeConn(con, opts)
+// shutdown stops accepting invocations and waits for the in-flight invocations to complete. Those still running
+// after the drain timeout are suspended, and the caller should cancel any which remain.
+func (r *Restate) shutdown(server *http.Server) {
+	r.systemLog.Info("Shutting down; draining in-flight invocations", "timeout", r.drainTimeout)
+
+	shutdownCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// closes the listener and sends GOAWAY to the open connections, so that Restate stops sending invocations on
+	// them. This waits for in-flight HTTP1.1 requests, so it must run alongside draining.
+	go server.Shutdown(shutdownCtx)
+
+	if r.invocations.drain(r.drainTimeout) {
+		r.systemLog.Info("All in-flight invocations completed")
+		return
 	}
+
+	r.systemLog.Warn("Drain timeout elapsed; suspending in-flight invocations", "invocations", r.invocations.suspend())
+
+	if r.invocations.drain(r.drainTimeout) {
+		r.systemLog.Info("All in-flight invocations suspended")
+		return
+	}
+
+	r.systemLog.Warn("Cancelling invocations which did not suspend")
 }
@@ -0,0 +1,401 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/internal/wire"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+)
+
+const manifestV1 = "application/vnd.restate.endpointmanifest.v1+json"
+
+// serve runs r on listener until the returned cancel function is called, which waits for Serve to return
+func serve(t *testing.T, r *Restate, listener net.Listener) (cancel func() error) {
+	t.Helper()
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- r.Serve(ctx, listener)
+	}()
+	t.Cleanup(cancelCtx)
+	return func() error {
+		cancelCtx()
+		return <-served
+	}
+}
+
+func listen(t *testing.T) net.Listener {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	return listener
+}
+
+// h2cClient makes cleartext HTTP2 requests with prior knowledge, dialling network regardless of the url
+func h2cClient(network, address string) *http.Client {
+	return &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, _, _ string, _ *tls.Config) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, address)
+		},
+	}}
+}
+
+func discover(t *testing.T, client *http.Client, url string) *http.Response {
+	t.Helper()
+	request, err := http.NewRequest(http.MethodGet, url+"/discover", nil)
+	require.NoError(t, err)
+	request.Header.Set("accept", manifestV1)
+	response, err := client.Do(request)
+	require.NoError(t, err)
+	t.Cleanup(func() { response.Body.Close() })
+	return response
+}
+
+func TestServeRequiresHTTP2(t *testing.T) {
+	listener := listen(t)
+	url := "http://" + listener.Addr().String()
+	stop := serve(t, NewRestate(), listener)
+
+	response := discover(t, http.DefaultClient, url)
+	require.Equal(t, 1, response.ProtoMajor)
+	require.Equal(t, http.StatusHTTPVersionNotSupported, response.StatusCode)
+
+	response = discover(t, h2cClient("tcp", listener.Addr().String()), url)
+	require.Equal(t, 2, response.ProtoMajor)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.NoError(t, stop())
+
+	t.Run("with HTTP1", func(t *testing.T) {
+		listener := listen(t)
+		stop := serve(t, NewRestate().WithHTTP1(true), listener)
+
+		response := discover(t, http.DefaultClient, "http://"+listener.Addr().String())
+		require.Equal(t, 1, response.ProtoMajor)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.Equal(t, manifestV1, response.Header.Get("content-type"))
+		require.NoError(t, stop())
+	})
+}
+
+func TestStartUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "restate.sock")
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan error, 1)
+	go func() {
+		started <- NewRestate().Start(ctx, "unix:"+socket)
+	}()
+
+	client := h2cClient("unix", socket)
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(socket)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	response := discover(t, client, "http://restate")
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	cancel()
+	require.NoError(t, <-started)
+}
+
+// writeCert writes a new self-signed certificate for 127.0.0.1 with the given serial number, and its key, to the
+// files, setting their modification time to modTime
+func writeCert(t *testing.T, certFile, keyFile string, serial int64, modTime time.Time) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "restate"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+}
+
+func TestTLSCertFilesReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	modTime := time.Now().Add(-time.Minute)
+	writeCert(t, certFile, keyFile, 1, modTime)
+
+	listener := listen(t)
+	stop := serve(t, NewRestate().WithTLSCertFiles(certFile, keyFile), listener)
+
+	// each handshake is on a new connection, so that it sees the current certificate
+	handshake := func() (serial int64, protocol string) {
+		conn, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{
+			InsecureSkipVerify: true,
+			NextProtos:         []string{http2.NextProtoTLS, "http/1.1"},
+		})
+		require.NoError(t, err)
+		defer conn.Close()
+		state := conn.ConnectionState()
+		return state.PeerCertificates[0].SerialNumber.Int64(), state.NegotiatedProtocol
+	}
+
+	serial, negotiated := handshake()
+	require.EqualValues(t, 1, serial)
+	require.Equal(t, http2.NextProtoTLS, negotiated)
+
+	writeCert(t, certFile, keyFile, 2, modTime.Add(time.Second))
+	serial, _ = handshake()
+	require.EqualValues(t, 2, serial)
+
+	// a half written rotation keeps the previous certificate
+	require.NoError(t, os.WriteFile(keyFile, []byte("not a key"), 0o600))
+	require.NoError(t, os.Chtimes(keyFile, modTime.Add(2*time.Second), modTime.Add(2*time.Second)))
+	serial, _ = handshake()
+	require.EqualValues(t, 2, serial)
+
+	require.NoError(t, stop())
+}
+
+// invocationBody encodes the start of an invocation with the given input, as sent by Restate
+func invocationBody(t *testing.T, input []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	p := wire.NewProtocol(&buf)
+	require.NoError(t, p.Write(wire.StartMessageType, &wire.StartMessage{StartMessage: protocol.StartMessage{
+		Id:           []byte("invocation"),
+		DebugId:      "inv_1",
+		KnownEntries: 1,
+	}}))
+	require.NoError(t, p.Write(wire.InputEntryMessageType, &wire.InputEntryMessage{InputEntryMessage: protocol.InputEntryMessage{
+		Value: input,
+	}}))
+	return buf.Bytes()
+}
+
+type readWriter struct {
+	io.Reader
+}
+
+func (readWriter) Write([]byte) (int, error) { panic("unexpected write") }
+
+func readMessages(t *testing.T, body io.Reader) []wire.Message {
+	t.Helper()
+	p := wire.NewProtocol(readWriter{body})
+	var messages []wire.Message
+	for {
+		msg, _, err := p.Read()
+		if err != nil {
+			return messages
+		}
+		messages = append(messages, msg)
+	}
+}
+
+// invoke starts an invocation of handler of the Greeter service, with a request body which is kept open until the
+// response has been read, and returns its response messages once they have been read
+func invoke(t *testing.T, client *http.Client, url, handler string) <-chan []wire.Message {
+	t.Helper()
+	body, writer := io.Pipe()
+	request, err := http.NewRequest(http.MethodPost, url+"/invoke/Greeter/"+handler, body)
+	require.NoError(t, err)
+	request.Header.Set("content-type", "application/vnd.restate.invocation.v1")
+
+	go writer.Write(invocationBody(t, []byte(`"bob"`)))
+
+	messages := make(chan []wire.Message, 1)
+	go func() {
+		defer writer.Close()
+		response, err := client.Do(request)
+		if err != nil {
+			messages <- nil
+			return
+		}
+		defer response.Body.Close()
+		messages <- readMessages(t, response.Body)
+	}()
+	return messages
+}
+
+func TestDrain(t *testing.T) {
+	entered := make(chan struct{}, 1)
+	release := make(chan struct{})
+	greeter := restate.NewService("Greeter").
+		Handler("greet", restate.NewServiceHandler(func(ctx restate.Context, name string) (string, error) {
+			entered <- struct{}{}
+			<-release
+			return "hello " + name, nil
+		})).
+		Handler("greetLater", restate.NewServiceHandler(func(ctx restate.Context, name string) (string, error) {
+			entered <- struct{}{}
+			if err := ctx.Sleep(time.Hour); err != nil {
+				return "", err
+			}
+			return "hello " + name, nil
+		}))
+
+	t.Run("waits for in-flight invocations", func(t *testing.T) {
+		listener := listen(t)
+		stop := serve(t, NewRestate().Bind(greeter).WithDrainTimeout(10*time.Second), listener)
+
+		messages := invoke(t, h2cClient("tcp", listener.Addr().String()), "http://"+listener.Addr().String(), "greet")
+		<-entered
+
+		stopped := make(chan error, 1)
+		go func() { stopped <- stop() }()
+
+		select {
+		case <-stopped:
+			t.Fatal("Serve returned before the in-flight invocation completed")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		close(release)
+		response := <-messages
+		require.Len(t, response, 2)
+		output, ok := response[0].(*wire.OutputEntryMessage)
+		require.True(t, ok)
+		require.Equal(t, []byte(`"hello bob"`), output.GetValue())
+		require.IsType(t, &wire.EndMessage{}, response[1])
+		require.NoError(t, <-stopped)
+	})
+
+	t.Run("suspends invocations after the timeout", func(t *testing.T) {
+		listener := listen(t)
+		stop := serve(t, NewRestate().Bind(greeter).WithDrainTimeout(100*time.Millisecond), listener)
+
+		messages := invoke(t, h2cClient("tcp", listener.Addr().String()), "http://"+listener.Addr().String(), "greetLater")
+		<-entered
+
+		start := time.Now()
+		require.NoError(t, stop())
+		require.Less(t, time.Since(start), 5*time.Second)
+
+		response := <-messages
+		require.Len(t, response, 2)
+		require.IsType(t, &wire.SleepEntryMessage{}, response[0])
+		suspension, ok := response[1].(*wire.SuspensionMessage)
+		require.True(t, ok)
+		require.Equal(t, []uint32{1}, suspension.EntryIndexes)
+	})
+}
+
+func TestInvocationsRejectedWhileDraining(t *testing.T) {
+	var i invocations
+	require.True(t, i.drain(time.Second))
+	require.False(t, i.add(nil))
+}
+
+// wrappedDefinition implements restate.ServiceDefinition without LazyState, like definitions outside of the SDK
+type wrappedDefinition struct {
+	restate.ServiceDefinition
+}
+
+func TestDiscoverLazyState(t *testing.T) {
+	object := func(name string) restate.ServiceDefinition {
+		return restate.NewObject(name).Handler("get", restate.NewObjectHandler(func(ctx restate.ObjectContext, _ restate.Void) (restate.Void, error) {
+			return restate.Void{}, nil
+		}))
+	}
+
+	discover := func(definition restate.ServiceDefinition, accept string) (string, map[string]any) {
+		handler, err := NewRestate().WithLazyState(true).Bind(definition).Handler()
+		require.NoError(t, err)
+
+		request := httptest.NewRequest(http.MethodGet, "/discover", nil)
+		request.Header.Set("accept", accept)
+		recorder := httptest.NewRecorder()
+		handler(recorder, request)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var manifest struct {
+			Services []map[string]any `json:"services"`
+		}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &manifest))
+		require.Len(t, manifest.Services, 1)
+		return recorder.Header().Get("content-type"), manifest.Services[0]
+	}
+
+	contentType, service := discover(object("Lazy"), manifestV1+", application/vnd.restate.endpointmanifest.v2+json")
+	require.Equal(t, "application/vnd.restate.endpointmanifest.v2+json", contentType)
+	require.Equal(t, true, service["enableLazyState"])
+
+	// V1 does not define enableLazyState
+	contentType, service = discover(object("Lazy"), manifestV1)
+	require.Equal(t, manifestV1, contentType)
+	require.NotContains(t, service, "enableLazyState")
+
+	// definitions without LazyState use the default of the server
+	_, service = discover(wrappedDefinition{object("Wrapped")}, "application/vnd.restate.endpointmanifest.v2+json")
+	require.Equal(t, true, service["enableLazyState"])
+}
+
+type approval struct{}
+
+func (approval) Run(ctx restate.WorkflowContext, _ restate.Void) (string, error) {
+	return restate.PromiseAs[string](ctx, "approval").Result()
+}
+
+func (approval) Approve(ctx restate.WorkflowSharedContext, decision string) (restate.Void, error) {
+	return restate.Void{}, restate.PromiseAs[string](ctx, "approval").Resolve(decision)
+}
+
+type doubleApproval struct {
+	approval
+}
+
+func (doubleApproval) Escalate(ctx restate.WorkflowContext, _ restate.Void) (string, error) {
+	return "", nil
+}
+
+func TestBindWorkflow(t *testing.T) {
+	run := restate.NewWorkflowHandler(func(ctx restate.WorkflowContext, _ restate.Void) (restate.Void, error) {
+		return restate.Void{}, nil
+	})
+	shared := restate.NewWorkflowSharedHandler(func(ctx restate.WorkflowSharedContext, _ restate.Void) (restate.Void, error) {
+		return restate.Void{}, nil
+	})
+
+	require.NotPanics(t, func() {
+		NewRestate().Bind(restate.NewWorkflow("Approval").Handler("run", run).Handler("status", shared))
+	})
+	require.NotPanics(t, func() {
+		NewRestate().Bind(restate.Workflow(approval{}))
+	})
+
+	require.PanicsWithValue(t, "workflow Approval must have exactly one run handler, but has 0: []", func() {
+		NewRestate().Bind(restate.NewWorkflow("Approval").Handler("status", shared))
+	})
+	require.PanicsWithValue(t, "workflow Approval must have exactly one run handler, but has 2: [escalate run]", func() {
+		NewRestate().Bind(restate.NewWorkflow("Approval").Handler("run", run).Handler("escalate", run))
+	})
+	require.PanicsWithValue(t, "workflow doubleApproval must have exactly one run handler, but has 2: [Escalate Run]", func() {
+		NewRestate().Bind(restate.Workflow(doubleApproval{}))
+	})
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// certReloader loads a certificate and key from files, reloading them when either file is modified so that
+// rotated certificates are picked up without a restart
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mutex    sync.Mutex
+	cert     *tls.Certificate
+	modTimes [2]time.Time
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	c := &certReloader{certFile: certFile, keyFile: keyFile}
+	if _, err := c.GetCertificate(nil); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetCertificate implements [tls.Config.GetCertificate]. If the files have been modified but cannot be loaded,
+// eg because only one has been replaced so far, the previously loaded certificate is used.
+func (c *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	modTimes, err := c.stat()
+	if err != nil {
+		if c.cert != nil {
+			return c.cert, nil
+		}
+		return nil, err
+	}
+	if c.cert != nil && modTimes == c.modTimes {
+		return c.cert, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		if c.cert != nil {
+			return c.cert, nil
+		}
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	c.cert = &cert
+	c.modTimes = modTimes
+	return c.cert, nil
+}
+
+func (c *certReloader) stat() (modTimes [2]time.Time, err error) {
+	for i, file := range []string{c.certFile, c.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return modTimes, fmt.Errorf("failed to stat TLS certificate file: %w", err)
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
@@ -0,0 +1,212 @@
+package restate
+
+import (
+	"bytes"
+	"encoding/binary"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/restatedev/sdk-go/encoding"
+	"github.com/restatedev/sdk-go/internal/options"
+)
+
+// StateKey describes a key of Virtual Object or Workflow state, along with the type of its value and the codec
+// with which the value is (de)serialised. Declaring each key once, and only accessing it through its StateKey,
+// ensures that every handler reads and writes the key with the same type and encoding.
+//
+//	var cart = restate.NewStateKey[[]Item]("cart", restate.WithProto).WithDefault(nil)
+//
+//	items, err := cart.Get(ctx)
+//
+// As state outlives the deployments that wrote it, the type of a key can be changed by giving it a new version
+// with WithVersion, and registering a [StateMigration] for each older version with WithMigration.
+type StateKey[T any] struct {
+	name         string
+	codec        encoding.Codec
+	defaultValue *T
+	version      uint
+	migrations   map[uint]StateMigration[T]
+}
+
+// StateMigration decodes a value which was stored by an older version of a [StateKey], given the codec of the key,
+// and converts it to the current type. See [MigrateFrom].
+type StateMigration[T any] func(codec encoding.Codec, data []byte) (T, error)
+
+// MigrateFrom creates a [StateMigration] which decodes the stored value as an Old, and converts it to the current
+// type with upgrade. The value is decoded with the codec of the key unless one is provided with [WithCodec],
+// [WithProto], [WithBinary] or [WithJSON], for example if the codec was changed along with the type.
+func MigrateFrom[Old any, T any](upgrade func(old Old) (T, error), opts ...options.StateKeyOption) StateMigration[T] {
+	o := options.StateKeyOptions{}
+	for _, opt := range opts {
+		opt.BeforeStateKey(&o)
+	}
+	return func(codec encoding.Codec, data []byte) (output T, err error) {
+		if o.Codec != nil {
+			codec = o.Codec
+		}
+		var old Old
+		if err := encoding.Unmarshal(codec, data, &old); err != nil {
+			return output, err
+		}
+		return upgrade(old)
+	}
+}
+
+// NewStateKey creates a StateKey for the key name. The value is (de)serialised with JSON unless a codec is
+// provided with [WithCodec], [WithProto], [WithBinary] or [WithJSON]. Only keys encoded with JSON or protobuf can
+// be versioned with WithVersion.
+func NewStateKey[T any](name string, opts ...options.StateKeyOption) StateKey[T] {
+	o := options.StateKeyOptions{}
+	for _, opt := range opts {
+		opt.BeforeStateKey(&o)
+	}
+	if o.Codec == nil {
+		o.Codec = encoding.JSONCodec
+	}
+	return StateKey[T]{name: name, codec: o.Codec}
+}
+
+// WithDefault returns a copy of the StateKey which Get will return value for instead of [ErrKeyNotFound]
+// if the key has no value.
+func (k StateKey[T]) WithDefault(value T) StateKey[T] {
+	k.defaultValue = &value
+	return k
+}
+
+// WithVersion returns a copy of the StateKey whose values are of the given version. Values are stored along with
+// their version, except at version 0, which is the version of values stored before a version was first given, so
+// that they can be told apart from newer values. Reading a value of an older version requires a migration to be
+// registered for it with WithMigration, and reading a value of a newer version fails with a terminal error.
+// It panics if the key isn't encoded with JSON or protobuf, as other encodings, such as [WithBinary], could store
+// values which are indistinguishable from versioned ones.
+func (k StateKey[T]) WithVersion(version uint) StateKey[T] {
+	if version != 0 && !k.versionable() {
+		panic(fmt.Sprintf("state key %s can't be versioned, as only values encoded with JSON or protobuf can be told apart from versioned values", k.name))
+	}
+	k.version = version
+	return k
+}
+
+// WithMigration returns a copy of the StateKey which uses migration to read values stored at version, which must be
+// older than the version of the key.
+func (k StateKey[T]) WithMigration(version uint, migration StateMigration[T]) StateKey[T] {
+	migrations := make(map[uint]StateMigration[T], len(k.migrations)+1)
+	for v, m := range k.migrations {
+		migrations[v] = m
+	}
+	migrations[version] = migration
+	k.migrations = migrations
+	return k
+}
+
+// Name returns the name of the key
+func (k StateKey[T]) Name() string {
+	return k.name
+}
+
+// Version returns the version of the values of the key
+func (k StateKey[T]) Version() uint {
+	return k.version
+}
+
+// Get gets the value of the key, migrating it if it was stored by an older version. If the key has no value, the
+// default is returned if one was provided with WithDefault, and otherwise [ErrKeyNotFound].
+func (k StateKey[T]) Get(ctx KeyValueReader) (output T, err error) {
+	output, _, err = k.get(ctx)
+	return
+}
+
+// Upgrade gets the value of the key like Get and, if it was stored by an older version, stores it again in the
+// current version so that the migration doesn't need to be repeated on later reads. As it writes state, it can
+// only be used in exclusive-mode handlers.
+func (k StateKey[T]) Upgrade(ctx interface {
+	KeyValueReader
+	KeyValueWriter
+}) (output T, err error) {
+	output, migrated, err := k.get(ctx)
+	if err != nil || !migrated {
+		return output, err
+	}
+	return output, k.Set(ctx, output)
+}
+
+// Set sets the value of the key, stored at the current version
+func (k StateKey[T]) Set(ctx KeyValueWriter, value T) error {
+	if k.version == 0 {
+		return ctx.Set(k.name, value, WithCodec(k.codec))
+	}
+
+	data, err := encoding.Marshal(k.codec, value)
+	if err != nil {
+		return TerminalError(fmt.Errorf("failed to marshal Set value: %w", err))
+	}
+	return ctx.Set(k.name, appendVersionHeader(data, k.version), WithBinary)
+}
+
+// Clear deletes the key
+func (k StateKey[T]) Clear(ctx KeyValueWriter) {
+	ctx.Clear(k.name)
+}
+
+// get returns the value of the key, and whether it was migrated from an older version
+func (k StateKey[T]) get(ctx KeyValueReader) (output T, migrated bool, err error) {
+	var stored []byte
+	if err = ctx.Get(k.name, &stored, WithBinary); err != nil {
+		if stderrors.Is(err, ErrKeyNotFound) && k.defaultValue != nil {
+			return *k.defaultValue, false, nil
+		}
+		return
+	}
+
+	version, data := uint(0), stored
+	if k.versionable() {
+		version, data = readVersionHeader(stored)
+	}
+	switch {
+	case version == k.version:
+		if err := encoding.Unmarshal(k.codec, data, &output); err != nil {
+			return output, false, TerminalError(fmt.Errorf("failed to unmarshal Get state into output: %w", err))
+		}
+		return output, false, nil
+	case version > k.version:
+		return output, false, TerminalError(fmt.Errorf("state key %s has a value of version %d, which is newer than the version %d of the key", k.name, version, k.version))
+	}
+
+	migration, ok := k.migrations[version]
+	if !ok {
+		return output, false, TerminalError(fmt.Errorf("state key %s has a value of version %d, which has no migration to version %d", k.name, version, k.version))
+	}
+	output, err = migration(k.codec, data)
+	if err != nil {
+		return output, false, TerminalError(fmt.Errorf("failed to migrate state key %s from version %d: %w", k.name, version, err))
+	}
+	return output, true, nil
+}
+
+// versionHeader precedes the version of a versioned state value. Its leading zero byte can't start a JSON or
+// protobuf encoding, so values stored before the key was versioned aren't mistaken for versioned ones. Values of
+// other encodings could start with it, and so are never read as versioned.
+var versionHeader = []byte{0, 'r', 's', 'v'}
+
+// versionable returns whether the values of the key can be told apart from versioned ones
+func (k StateKey[T]) versionable() bool {
+	return k.codec == encoding.JSONCodec || k.codec == encoding.ProtoCodec
+}
+
+func appendVersionHeader(data []byte, version uint) []byte {
+	stored := make([]byte, 0, len(versionHeader)+binary.MaxVarintLen64+len(data))
+	stored = append(stored, versionHeader...)
+	stored = binary.AppendUvarint(stored, uint64(version))
+	return append(stored, data...)
+}
+
+func readVersionHeader(stored []byte) (uint, []byte) {
+	if !bytes.HasPrefix(stored, versionHeader) {
+		return 0, stored
+	}
+	version, n := binary.Uvarint(stored[len(versionHeader):])
+	if n <= 0 {
+		return 0, stored
+	}
+	return uint(version), stored[len(versionHeader)+n:]
+}
@@ -0,0 +1,164 @@
+package restate_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/internal/options"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/stretchr/testify/require"
+)
+
+// wrappingReader wraps the errors of Get, as middleware or helpers might
+type wrappingReader struct {
+	restate.ObjectContext
+}
+
+func (r wrappingReader) Get(key string, value any, opts ...options.GetOption) error {
+	if err := r.ObjectContext.Get(key, value, opts...); err != nil {
+		return fmt.Errorf("getting %s: %w", key, err)
+	}
+	return nil
+}
+
+func TestStateKey(t *testing.T) {
+	count := restate.NewStateKey[int]("count").WithDefault(10)
+	blob := restate.NewStateKey[[]byte]("blob", restate.WithBinary)
+	stale := restate.NewStateKey[bool]("stale")
+
+	handler := restate.NewObjectHandler(func(ctx restate.ObjectContext, delta int) (int, error) {
+		// the default is used even if the error is wrapped
+		value, err := count.Get(wrappingReader{ctx})
+		if err != nil {
+			return 0, err
+		}
+		data, err := blob.Get(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := stale.Get(ctx); !errors.Is(err, restate.ErrKeyNotFound) {
+			return 0, fmt.Errorf("expected key without default to be missing, got %v", err)
+		}
+		if err := blob.Set(ctx, append(data, '!')); err != nil {
+			return 0, err
+		}
+		value += delta
+		return value, count.Set(ctx, value)
+	})
+	restate.NewObject("Counter").Handler("add", handler)
+
+	runtime := restatetest.Runtime{
+		Key:   "my-counter",
+		State: map[string][]byte{"blob": []byte("raw")},
+	}
+
+	result := runtime.Complete(t, handler, restatetest.JSON(t, 2))
+	require.NoError(t, result.TerminalError)
+	require.Equal(t, restatetest.JSON(t, 12), result.Output)
+	require.Equal(t, map[string][]byte{"count": restatetest.JSON(t, 12), "blob": []byte("raw!")}, result.State)
+}
+
+type cartV2 struct {
+	Items []string `json:"items"`
+}
+
+func TestStateKeyMigration(t *testing.T) {
+	// version 0 stored a comma separated string, version 1 a list, and version 2 a struct
+	cart := restate.NewStateKey[cartV2]("cart").
+		WithVersion(2).
+		WithMigration(0, restate.MigrateFrom(func(old string) (cartV2, error) {
+			return cartV2{Items: strings.Split(old, ",")}, nil
+		})).
+		WithMigration(1, restate.MigrateFrom(func(old []string) (cartV2, error) {
+			return cartV2{Items: old}, nil
+		}))
+	cartV1 := restate.NewStateKey[[]string]("cart").WithVersion(1)
+
+	handler := restate.NewObjectHandler(func(ctx restate.ObjectContext, upgrade bool) ([]string, error) {
+		var value cartV2
+		var err error
+		if upgrade {
+			value, err = cart.Upgrade(ctx)
+		} else {
+			value, err = cart.Get(ctx)
+		}
+		return value.Items, err
+	})
+	restate.NewObject("Cart").Handler("get", handler)
+
+	versioned := restatetest.Runtime{Key: "cart"}
+	setup := restate.NewObjectHandler(func(ctx restate.ObjectContext, _ restate.Void) (restate.Void, error) {
+		return restate.Void{}, cartV1.Set(ctx, []string{"apple", "pear"})
+	})
+	restate.NewObject("CartV1").Handler("set", setup)
+	result, err := versioned.Invoke(context.Background(), setup, nil)
+	require.NoError(t, err)
+	require.True(t, result.Completed())
+
+	for name, state := range map[string][]byte{
+		"unversioned": restatetest.JSON(t, "apple,pear"),
+		"version 1":   result.State["cart"],
+	} {
+		t.Run(name, func(t *testing.T) {
+			runtime := restatetest.Runtime{Key: "cart", State: map[string][]byte{"cart": state}}
+
+			result, err := runtime.Invoke(context.Background(), handler, restatetest.JSON(t, false))
+			require.NoError(t, err)
+			require.NoError(t, result.TerminalError)
+			require.Equal(t, restatetest.JSON(t, []string{"apple", "pear"}), result.Output)
+			require.Equal(t, state, result.State["cart"])
+			require.NoError(t, runtime.CheckDeterminism(context.Background(), handler, restatetest.JSON(t, false)))
+
+			result, err = runtime.Invoke(context.Background(), handler, restatetest.JSON(t, true))
+			require.NoError(t, err)
+			require.NoError(t, result.TerminalError)
+			require.Equal(t, restatetest.JSON(t, []string{"apple", "pear"}), result.Output)
+			require.NotEqual(t, state, result.State["cart"])
+			require.NoError(t, runtime.CheckDeterminism(context.Background(), handler, restatetest.JSON(t, true)))
+
+			// the upgraded value is read without a migration
+			runtime.State = result.State
+			result, err = runtime.Invoke(context.Background(), handler, restatetest.JSON(t, true))
+			require.NoError(t, err)
+			require.NoError(t, result.TerminalError)
+			require.Equal(t, restatetest.JSON(t, []string{"apple", "pear"}), result.Output)
+			require.Equal(t, runtime.State["cart"], result.State["cart"])
+		})
+	}
+
+	// a value written by a newer version can't be read
+	newer := restate.NewObjectHandler(func(ctx restate.ObjectContext, _ restate.Void) ([]string, error) {
+		return cartV1.Get(ctx)
+	})
+	restate.NewObject("CartNewer").Handler("get", newer)
+	upgraded := restatetest.Runtime{Key: "cart", State: map[string][]byte{"cart": restatetest.JSON(t, "apple")}}
+	result, err = upgraded.Invoke(context.Background(), handler, restatetest.JSON(t, true))
+	require.NoError(t, err)
+	require.NoError(t, result.TerminalError)
+
+	runtime := restatetest.Runtime{Key: "cart", State: result.State}
+	result, err = runtime.Invoke(context.Background(), newer, nil)
+	require.NoError(t, err)
+	require.ErrorContains(t, result.TerminalError, "version 2, which is newer than the version 1")
+}
+
+func TestUnversionableStateKey(t *testing.T) {
+	blob := restate.NewStateKey[[]byte]("blob", restate.WithBinary)
+	require.Panics(t, func() { blob.WithVersion(1) })
+
+	handler := restate.NewObjectHandler(func(ctx restate.ObjectContext, _ restate.Void) ([]byte, error) {
+		return blob.Get(ctx)
+	})
+	restate.NewObject("Blob").Handler("get", handler)
+
+	// the value starts like a versioned one, but is read as it is
+	stored := []byte{0, 'r', 's', 'v', 5, 'x'}
+	runtime := restatetest.Runtime{Key: "blob", State: map[string][]byte{"blob": stored}}
+	result := runtime.Complete(t, handler, nil)
+	require.NoError(t, result.TerminalError)
+	require.Equal(t, restatetest.JSON(t, stored), result.Output)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	restate "github.com/restatedev/sdk-go"
+)
+
+const MY_STATE = "my-state"
+const MY_DURABLE_PROMISE = "durable-promise"
+
+func init() {
+	REGISTRY.AddDefinition(
+		restate.NewWorkflow("WorkflowAPIBlockAndWait").
+			Handler("run", restate.NewWorkflowHandler(
+				func(ctx restate.WorkflowContext, input string) (string, error) {
+					if err := ctx.Set(MY_STATE, input); err != nil {
+						return "", err
+					}
+					output, err := restate.PromiseAs[string](ctx, MY_DURABLE_PROMISE).Result()
+					if err != nil {
+						return "", err
+					}
+
+					_, ok, err := restate.PromiseAs[string](ctx, MY_DURABLE_PROMISE).Peek()
+					if err != nil {
+						return "", err
+					}
+					if !ok {
+						return "", restate.TerminalError(fmt.Errorf("Durable promise should be completed"))
+					}
+
+					return output, nil
+				})).
+			Handler("unblock", restate.NewWorkflowSharedHandler(
+				func(ctx restate.WorkflowSharedContext, output string) (restate.Void, error) {
+					return restate.Void{}, restate.PromiseAs[string](ctx, MY_DURABLE_PROMISE).Resolve(output)
+				})).
+			Handler("getState", restate.NewWorkflowSharedHandler(
+				func(ctx restate.WorkflowSharedContext, input restate.Void) (*string, error) {
+					state, err := restate.GetAs[string](ctx, MY_STATE)
+					if err == restate.ErrKeyNotFound {
+						return nil, nil
+					} else if err != nil {
+						return nil, err
+					}
+					return &state, nil
+				})))
+}
@@ -0,0 +1,105 @@
+// Package opentelemetry implements [tracing.Tracer] with OpenTelemetry.
+//
+//	server.NewRestate().WithTracer(opentelemetry.NewTracer(otel.GetTracerProvider()))
+package opentelemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/restatedev/sdk-go/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/restatedev/sdk-go"
+
+// traceContext propagates W3C trace context, as sent by the runtime in the attempt headers
+var traceContext propagation.TextMapPropagator = propagation.TraceContext{}
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+var _ tracing.Tracer = tracer{}
+
+// NewTracer creates a tracer which starts a server span for each attempt with provider, parented on the trace
+// context sent by Restate
+func NewTracer(provider trace.TracerProvider) tracing.Tracer {
+	return tracer{provider.Tracer(tracerName)}
+}
+
+func (t tracer) StartAttempt(ctx context.Context, service, handler string, header http.Header) (context.Context, tracing.Span) {
+	ctx, s := t.tracer.Start(
+		traceContext.Extract(ctx, propagation.HeaderCarrier(header)),
+		fmt.Sprintf("%s/%s", service, handler),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "restate"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", handler),
+		),
+	)
+	return ctx, span{s}
+}
+
+type span struct {
+	span trace.Span
+}
+
+var _ tracing.Span = span{}
+
+func (s span) Start(ctx context.Context, name string, attributes ...tracing.Attribute) (context.Context, tracing.Span) {
+	ctx, child := s.span.TracerProvider().Tracer(tracerName).Start(
+		trace.ContextWithSpan(ctx, s.span), name, trace.WithAttributes(convert(attributes)...))
+	return ctx, span{child}
+}
+
+func (s span) SetAttributes(attributes ...tracing.Attribute) {
+	s.span.SetAttributes(convert(attributes)...)
+}
+
+func (s span) AddEvent(name string, attributes ...tracing.Attribute) {
+	s.span.AddEvent(name, trace.WithAttributes(convert(attributes)...))
+}
+
+func (s span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s span) SetError(description string) {
+	s.span.SetStatus(codes.Error, description)
+}
+
+func (s span) End(at time.Time) {
+	s.span.End(trace.WithTimestamp(at))
+}
+
+func (s span) TraceContext() (traceparent, tracestate string) {
+	if !s.span.SpanContext().IsValid() {
+		return "", ""
+	}
+	carrier := propagation.MapCarrier{}
+	traceContext.Inject(trace.ContextWithSpan(context.Background(), s.span), carrier)
+	return carrier.Get("traceparent"), carrier.Get("tracestate")
+}
+
+func convert(attributes []tracing.Attribute) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attributes))
+	for _, a := range attributes {
+		switch value := a.Value.(type) {
+		case string:
+			kvs = append(kvs, attribute.String(a.Key, value))
+		case int64:
+			kvs = append(kvs, attribute.Int64(a.Key, value))
+		default:
+			kvs = append(kvs, attribute.String(a.Key, fmt.Sprint(value)))
+		}
+	}
+	return kvs
+}
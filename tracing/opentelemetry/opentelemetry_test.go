@@ -0,0 +1,65 @@
+package opentelemetry_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/restatedev/sdk-go/generated/proto/protocol"
+	"github.com/restatedev/sdk-go/restatetest"
+	"github.com/restatedev/sdk-go/tracing"
+	"github.com/restatedev/sdk-go/tracing/opentelemetry"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	handler := restate.NewServiceHandler(func(ctx restate.Context, _ restate.Void) (restate.Void, error) {
+		if err := ctx.Sleep(time.Second); err != nil {
+			return restate.Void{}, err
+		}
+		if err := ctx.Service("Greeter", "greet").Send("bob", 0); err != nil {
+			return restate.Void{}, err
+		}
+		return restate.RunAs(ctx, func(ctx restate.RunContext) (restate.Void, error) {
+			require.True(t, trace.SpanFromContext(ctx).SpanContext().IsValid())
+			return restate.Void{}, nil
+		}, restate.WithName("charge-card"))
+	})
+	restate.NewService("Test").Handler("handle", handler)
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := opentelemetry.NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	header := http.Header{"Traceparent": {"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}}
+	ctx, attempt := tracer.StartAttempt(context.Background(), "Test", "handle", header)
+	ctx = tracing.ContextWithSpan(ctx, attempt)
+
+	var runtime restatetest.Runtime
+	result, err := runtime.Invoke(ctx, handler, nil)
+	attempt.End(time.Now())
+	require.NoError(t, err)
+	require.True(t, result.Completed())
+
+	traceID := trace.SpanFromContext(ctx).SpanContext().TraceID()
+	require.Equal(t, "0af7651916cd43dd8448eb211c80319c", traceID.String())
+
+	spans := recorder.Ended()
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name())
+		require.Equal(t, traceID, span.SpanContext().TraceID())
+	}
+	require.Equal(t, []string{"sleep", "run charge-card", "Test/handle"}, names)
+
+	call, ok := result.Journal[1].(*protocol.OneWayCallEntryMessage)
+	require.True(t, ok)
+	require.Len(t, call.Headers, 1)
+	require.Equal(t, "traceparent", call.Headers[0].Key)
+	require.Contains(t, call.Headers[0].Value, traceID.String())
+
+	require.NoError(t, runtime.CheckDeterminism(ctx, handler, nil))
+}
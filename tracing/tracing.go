@@ -0,0 +1,69 @@
+// Package tracing provides hooks to trace the invocations handled by a [github.com/restatedev/sdk-go/server.Restate]
+// server, without depending on a tracing library. An implementation using OpenTelemetry is provided by
+// [github.com/restatedev/sdk-go/tracing/opentelemetry].
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Attribute is a key and value annotating a span, where the value is a string or an int64
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String creates a string attribute
+func String(key, value string) Attribute {
+	return Attribute{key, value}
+}
+
+// Int64 creates an integer attribute
+func Int64(key string, value int64) Attribute {
+	return Attribute{key, value}
+}
+
+// Tracer starts a span for each attempt of an invocation. Implementations must be safe for concurrent use.
+type Tracer interface {
+	// StartAttempt starts the span of an attempt of the handler, given the headers of the request from Restate, which
+	// carry the W3C trace context of the invocation. It returns a context carrying the span for the tracing library.
+	StartAttempt(ctx context.Context, service, handler string, header http.Header) (context.Context, Span)
+}
+
+// Span is a span started by a [Tracer]. The SDK creates child spans for each Run and sleep of the attempt, and
+// records entries replayed from the journal as events rather than emitting their spans again.
+type Span interface {
+	// Start starts a child span, returning a context derived from ctx which carries it for the tracing library
+	Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span)
+	SetAttributes(attributes ...Attribute)
+	AddEvent(name string, attributes ...Attribute)
+	// RecordError records err on the span and marks it as failed
+	RecordError(err error)
+	// SetError marks the span as failed with the given description
+	SetError(description string)
+	// End ends the span at the given time, which is in the future for sleeps
+	End(at time.Time)
+	// TraceContext returns the W3C traceparent and tracestate of the span, which are propagated to outgoing calls.
+	// traceparent is empty if the span is not being recorded.
+	TraceContext() (traceparent, tracestate string)
+}
+
+// TraceContextHeaders are the keys of the headers with which the trace context is propagated to outgoing calls
+var TraceContextHeaders = []string{"traceparent", "tracestate"}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a context carrying span as the span of an attempt. The server does this with the span
+// returned by [Tracer.StartAttempt]; it is exported so that spans can be provided when running handlers with
+// [github.com/restatedev/sdk-go/restatetest].
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span of the attempt carried by ctx, or nil if there is none
+func SpanFromContext(ctx context.Context) Span {
+	span, _ := ctx.Value(spanKey{}).(Span)
+	return span
+}